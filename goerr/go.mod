@@ -1,4 +1,3 @@
 module github.com/l00pss/helpme/goerr
 
 go 1.25
-
@@ -1,8 +1,45 @@
 package goerr
 
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Error codes shared by all transports
+const (
+	CodeUnknown          = "UNKNOWN"
+	CodeInvalidArgument  = "INVALID_ARGUMENT"
+	CodeNotFound         = "NOT_FOUND"
+	CodeAlreadyExists    = "ALREADY_EXISTS"
+	CodePermissionDenied = "PERMISSION_DENIED"
+	CodeUnauthenticated  = "UNAUTHENTICATED"
+	CodeUnavailable      = "UNAVAILABLE"
+	CodeDeadlineExceeded = "DEADLINE_EXCEEDED"
+	CodeCanceled         = "CANCELED"
+	CodeInternal         = "INTERNAL"
+)
+
+// Severity classifies how urgently an error needs attention
+type Severity string
+
+const (
+	SeverityDebug    Severity = "debug"
+	SeverityWarning  Severity = "warning"
+	SeverityError    Severity = "error"
+	SeverityCritical Severity = "critical"
+)
+
 type GoErr struct {
 	error
+	msg        string
 	runtimeErr bool
+	code       string
+	retryable  bool
+	timeout    bool
+	severity   Severity
+	context    map[string]any
 }
 
 func newGoErr(err error, isRuntime bool) *GoErr {
@@ -16,14 +53,106 @@ func (g *GoErr) IsRuntime() bool {
 	return g.runtimeErr
 }
 
+// Code returns the error code, or an empty string if none was set
+func (g *GoErr) Code() string {
+	return g.code
+}
+
+// IsCode reports whether the error carries code
+func (g *GoErr) IsCode(code string) bool {
+	return g.code == code
+}
+
+// WithCode returns a copy of the error carrying the given code
+func (g *GoErr) WithCode(code string) *GoErr {
+	c := *g
+	c.code = code
+	return &c
+}
+
+// Severity returns the error severity, SeverityError if none was set
+func (g *GoErr) Severity() Severity {
+	if g.severity == "" {
+		return SeverityError
+	}
+	return g.severity
+}
+
+// WithSeverity returns a copy of the error carrying the given severity
+func (g *GoErr) WithSeverity(severity Severity) *GoErr {
+	c := *g
+	c.severity = severity
+	return &c
+}
+
+// Retryable reports whether the operation that failed may succeed if retried
+func (g *GoErr) Retryable() bool {
+	return g.retryable
+}
+
+// WithRetryable returns a copy of the error with the retryable flag set
+func (g *GoErr) WithRetryable(retryable bool) *GoErr {
+	c := *g
+	c.retryable = retryable
+	return &c
+}
+
+// Temporary reports the retryable flag, matching the net.Error convention
+func (g *GoErr) Temporary() bool {
+	return g.retryable
+}
+
+// Timeout reports whether the error was created by WrapTimeout
+func (g *GoErr) Timeout() bool {
+	return g.timeout
+}
+
 func (g *GoErr) Unwrap() error {
 	return g.error
 }
 
 func (g *GoErr) Error() string {
+	if g.msg != "" {
+		return g.msg + ": " + g.error.Error()
+	}
 	return g.error.Error()
 }
 
+// HideNonRuntimeDetails makes MarshalJSON replace the message of non-runtime
+// errors with HiddenMessage
+var HideNonRuntimeDetails = false
+
+// HiddenMessage is the message emitted in place of hidden error details
+const HiddenMessage = "internal error"
+
+type jsonError struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	Retryable bool   `json:"retryable"`
+}
+
+// MarshalJSON encodes the error as an API error response. Errors without a
+// code are reported as CodeUnknown.
+func (g *GoErr) MarshalJSON() ([]byte, error) {
+	body := jsonError{
+		Code:      g.code,
+		Message:   g.Error(),
+		Retryable: g.retryable,
+	}
+	if body.Code == "" {
+		body.Code = CodeUnknown
+	}
+	if HideNonRuntimeDetails && !g.runtimeErr {
+		body.Message = HiddenMessage
+	}
+	return json.Marshal(body)
+}
+
+// Root returns the deepest error wrapped by g
+func (g *GoErr) Root() error {
+	return Cause(g)
+}
+
 func WrapRuntimeErr(err error) *GoErr {
 	return newGoErr(err, true)
 }
@@ -32,7 +161,123 @@ func WrapNonRuntimeErr(err error) *GoErr {
 	return newGoErr(err, false)
 }
 
+// inheritGoErr wraps err in a GoErr that keeps the classification, code,
+// severity, retryable and timeout flags and context values of the first
+// GoErr in err's chain
+func inheritGoErr(err error) *GoErr {
+	var inner *GoErr
+	if !errors.As(err, &inner) {
+		return newGoErr(err, false)
+	}
+	return &GoErr{
+		error:      err,
+		runtimeErr: inner.runtimeErr,
+		code:       inner.code,
+		retryable:  inner.retryable,
+		timeout:    inner.timeout,
+		severity:   inner.severity,
+		context:    inner.context,
+	}
+}
+
+// Wrap annotates err with msg. The result keeps the attributes of the first
+// GoErr in err's chain, such as its code and runtime classification. Wrap
+// returns nil if err is nil.
+func Wrap(err error, msg string) *GoErr {
+	if err == nil {
+		return nil
+	}
+	g := inheritGoErr(err)
+	g.msg = msg
+	return g
+}
+
+// ContextKey is the type of the context keys WrapWithContext reads by
+// default. o4g_logger uses the same type and keys, so the IDs set with
+// o4g_logger.WithRequestID and WithUserID are captured as well.
+type ContextKey string
+
+const (
+	// RequestIDKey is the context key for the request ID
+	RequestIDKey ContextKey = "request_id"
+	// UserIDKey is the context key for the user ID
+	UserIDKey ContextKey = "user_id"
+)
+
+// WrapWithContext wraps err like Wrap without a message and stores the values
+// of RequestIDKey, UserIDKey and keys found in ctx, so they survive async
+// boundaries. Values are stored under fmt.Sprint(key), which lets callers
+// pass string based keys of other packages, such as the logger's.
+// WrapWithContext returns nil if err is nil.
+func WrapWithContext(ctx context.Context, err error, keys ...any) *GoErr {
+	if err == nil {
+		return nil
+	}
+	g := inheritGoErr(err)
+	g.context = g.Context()
+	for _, key := range append([]any{RequestIDKey, UserIDKey}, keys...) {
+		if value := ctx.Value(key); value != nil {
+			g.context[fmt.Sprint(key)] = value
+		}
+	}
+	return g
+}
+
+// Context returns a copy of the context values stored by WrapWithContext
+func (g *GoErr) Context() map[string]any {
+	values := make(map[string]any, len(g.context))
+	for k, v := range g.context {
+		values[k] = v
+	}
+	return values
+}
+
+// Wrapf is like Wrap with a formatted message
+func Wrapf(err error, format string, args ...any) *GoErr {
+	return Wrap(err, fmt.Sprintf(format, args...))
+}
+
+// WrapTimeout wraps err as a retryable runtime error that reports Timeout
+func WrapTimeout(err error) *GoErr {
+	g := newGoErr(err, true)
+	g.timeout = true
+	g.retryable = true
+	return g
+}
+
 func IsGoErr(err error) bool {
 	_, ok := err.(*GoErr)
 	return ok
 }
+
+// Cause unwraps err until it reaches an error that does not wrap another one
+func Cause(err error) error {
+	for err != nil {
+		next := errors.Unwrap(err)
+		if next == nil {
+			return err
+		}
+		err = next
+	}
+	return err
+}
+
+// HasCode reports whether any GoErr in err's chain carries code
+func HasCode(err error, code string) bool {
+	for err != nil {
+		if g, ok := err.(*GoErr); ok && g.IsCode(code) {
+			return true
+		}
+		err = errors.Unwrap(err)
+	}
+	return false
+}
+
+// SeverityOf returns the severity of the first GoErr in err's chain, or SeverityError
+func SeverityOf(err error) Severity {
+	var g *GoErr
+	if errors.As(err, &g) {
+		return g.Severity()
+	}
+	return SeverityError
+}
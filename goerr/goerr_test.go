@@ -1,7 +1,11 @@
 package goerr_test
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/l00pss/helpme/goerr"
@@ -108,3 +112,318 @@ func TestGoErr_IsRuntime(t *testing.T) {
 		t.Error("WrapNonRuntimeErr should create non-runtime error")
 	}
 }
+
+func TestGoErr_WithCode(t *testing.T) {
+	goErr := goerr.WrapNonRuntimeErr(errors.New("user not found"))
+	if goErr.Code() != "" {
+		t.Errorf("expected empty code, got '%s'", goErr.Code())
+	}
+
+	coded := goErr.WithCode(goerr.CodeNotFound)
+	if coded.Code() != goerr.CodeNotFound {
+		t.Errorf("expected '%s', got '%s'", goerr.CodeNotFound, coded.Code())
+	}
+	if coded.IsRuntime() {
+		t.Error("WithCode should keep the runtime classification")
+	}
+	if goErr.Code() != "" {
+		t.Error("WithCode should not modify the original error")
+	}
+}
+
+func TestGoErr_IsCode(t *testing.T) {
+	goErr := goerr.WrapNonRuntimeErr(errors.New("user not found")).WithCode(goerr.CodeNotFound)
+	if !goErr.IsCode(goerr.CodeNotFound) {
+		t.Error("expected IsCode to match the error code")
+	}
+	if goErr.IsCode(goerr.CodeInternal) {
+		t.Error("expected IsCode not to match a different code")
+	}
+}
+
+func TestHasCode(t *testing.T) {
+	inner := goerr.WrapNonRuntimeErr(errors.New("user not found")).WithCode(goerr.CodeNotFound)
+	wrapped := fmt.Errorf("get profile: %w", inner)
+
+	if !goerr.HasCode(wrapped, goerr.CodeNotFound) {
+		t.Error("expected HasCode to find the code through fmt.Errorf")
+	}
+	if goerr.HasCode(wrapped, goerr.CodeInternal) {
+		t.Error("expected HasCode not to match a different code")
+	}
+
+	outer := goerr.Wrap(wrapped, "handler").WithCode(goerr.CodeInternal)
+	if !goerr.HasCode(outer, goerr.CodeNotFound) || !goerr.HasCode(outer, goerr.CodeInternal) {
+		t.Error("expected HasCode to check every GoErr in the chain")
+	}
+	if goerr.HasCode(errors.New("plain"), goerr.CodeNotFound) || goerr.HasCode(nil, goerr.CodeNotFound) {
+		t.Error("expected HasCode to be false without a GoErr")
+	}
+}
+
+func TestGoErr_Timeout(t *testing.T) {
+	goErr := goerr.WrapTimeout(errors.New("i/o timeout"))
+	if !goErr.Timeout() {
+		t.Error("WrapTimeout should report Timeout")
+	}
+	if !goErr.Temporary() {
+		t.Error("WrapTimeout should report Temporary")
+	}
+
+	var netErr interface {
+		Timeout() bool
+		Temporary() bool
+	}
+	if !errors.As(fmt.Errorf("dial: %w", goErr), &netErr) || !netErr.Timeout() {
+		t.Error("expected wrapped timeout to match the net timeout interface")
+	}
+
+	plain := goerr.WrapRuntimeErr(errors.New("boom"))
+	if plain.Timeout() || plain.Temporary() {
+		t.Error("expected plain error to be neither timeout nor temporary")
+	}
+}
+
+func TestGoErr_WithRetryable(t *testing.T) {
+	goErr := goerr.WrapRuntimeErr(errors.New("service unavailable"))
+	retryable := goErr.WithRetryable(true)
+	if !retryable.Retryable() || !retryable.Temporary() {
+		t.Error("WithRetryable(true) should mark the error as retryable")
+	}
+	if retryable.Timeout() {
+		t.Error("WithRetryable should not mark the error as timeout")
+	}
+	if goErr.Retryable() {
+		t.Error("WithRetryable should not modify the original error")
+	}
+}
+
+func TestGoErr_WithSeverity(t *testing.T) {
+	goErr := goerr.WrapRuntimeErr(errors.New("disk almost full"))
+	if goErr.Severity() != goerr.SeverityError {
+		t.Errorf("expected default severity '%s', got '%s'", goerr.SeverityError, goErr.Severity())
+	}
+
+	critical := goErr.WithSeverity(goerr.SeverityCritical)
+	if critical.Severity() != goerr.SeverityCritical {
+		t.Errorf("expected '%s', got '%s'", goerr.SeverityCritical, critical.Severity())
+	}
+	if goErr.Severity() != goerr.SeverityError {
+		t.Error("WithSeverity should not modify the original error")
+	}
+}
+
+func TestSeverityOf(t *testing.T) {
+	warning := goerr.WrapNonRuntimeErr(errors.New("deprecated field")).WithSeverity(goerr.SeverityWarning)
+	if got := goerr.SeverityOf(fmt.Errorf("validate: %w", warning)); got != goerr.SeverityWarning {
+		t.Errorf("expected '%s', got '%s'", goerr.SeverityWarning, got)
+	}
+	if got := goerr.SeverityOf(errors.New("plain")); got != goerr.SeverityError {
+		t.Errorf("expected '%s' for non-GoErr, got '%s'", goerr.SeverityError, got)
+	}
+}
+
+func TestGoErr_MarshalJSON(t *testing.T) {
+	goErr := goerr.WrapRuntimeErr(errors.New("user 42 not found")).
+		WithCode(goerr.CodeNotFound).
+		WithRetryable(false)
+
+	data, err := json.Marshal(goErr)
+	if err != nil {
+		t.Fatalf("unexpected marshal error: %v", err)
+	}
+	expected := `{"code":"NOT_FOUND","message":"user 42 not found","retryable":false}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+
+	data, _ = json.Marshal(goerr.WrapTimeout(errors.New("upstream timed out")))
+	expected = `{"code":"UNKNOWN","message":"upstream timed out","retryable":true}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+}
+
+func TestGoErr_MarshalJSONHideNonRuntimeDetails(t *testing.T) {
+	goerr.HideNonRuntimeDetails = true
+	defer func() { goerr.HideNonRuntimeDetails = false }()
+
+	hidden, _ := json.Marshal(goerr.WrapNonRuntimeErr(errors.New("pq: connection refused")).WithCode(goerr.CodeInternal))
+	expected := `{"code":"INTERNAL","message":"internal error","retryable":false}`
+	if string(hidden) != expected {
+		t.Errorf("expected %s, got %s", expected, hidden)
+	}
+
+	visible, _ := json.Marshal(goerr.WrapRuntimeErr(errors.New("bad input")))
+	if !strings.Contains(string(visible), "bad input") {
+		t.Errorf("expected runtime error message to be kept, got %s", visible)
+	}
+}
+
+func TestCause(t *testing.T) {
+	root := errors.New("connection refused")
+	wrapped := goerr.WrapRuntimeErr(fmt.Errorf("query users: %w", goerr.WrapNonRuntimeErr(root)))
+	outer := fmt.Errorf("handler: %w", wrapped)
+
+	if got := goerr.Cause(outer); got != root {
+		t.Errorf("expected innermost error, got '%v'", got)
+	}
+	if got := wrapped.Root(); got != root {
+		t.Errorf("expected Root to return innermost error, got '%v'", got)
+	}
+	if got := goerr.Cause(root); got != root {
+		t.Error("expected unwrapped error to be its own cause")
+	}
+	if goerr.Cause(nil) != nil {
+		t.Error("expected nil cause for nil error")
+	}
+}
+
+func TestWrap(t *testing.T) {
+	orig := errors.New("connection refused")
+
+	wrapped := goerr.Wrap(orig, "load user")
+	if wrapped.Error() != "load user: connection refused" {
+		t.Errorf("unexpected message '%s'", wrapped.Error())
+	}
+	if wrapped.Unwrap() != orig {
+		t.Error("expected Unwrap to return the original error")
+	}
+	if wrapped.IsRuntime() {
+		t.Error("expected plain errors to be wrapped as non-runtime")
+	}
+
+	formatted := goerr.Wrapf(wrapped, "handle request %d", 42)
+	if formatted.Error() != "handle request 42: load user: connection refused" {
+		t.Errorf("unexpected message '%s'", formatted.Error())
+	}
+	if !errors.Is(formatted, orig) {
+		t.Error("expected errors.Is to find the original error")
+	}
+	if formatted.Unwrap() != wrapped {
+		t.Error("expected Unwrap to return the wrapped GoErr")
+	}
+
+	if goerr.Wrap(nil, "nothing") != nil {
+		t.Error("expected nil for nil error")
+	}
+}
+
+func TestWrapInheritsRuntime(t *testing.T) {
+	runtime := goerr.Wrap(goerr.WrapRuntimeErr(errors.New("bad input")), "validate")
+	if !runtime.IsRuntime() {
+		t.Error("expected runtime classification to be inherited")
+	}
+
+	nonRuntime := goerr.Wrap(goerr.WrapNonRuntimeErr(errors.New("disk full")), "save")
+	if nonRuntime.IsRuntime() {
+		t.Error("expected non-runtime classification to be inherited")
+	}
+}
+
+func TestWrapInheritsAttributes(t *testing.T) {
+	inner := goerr.WrapRuntimeErr(errors.New("user 42 not found")).
+		WithCode(goerr.CodeNotFound).
+		WithSeverity(goerr.SeverityCritical).
+		WithRetryable(true)
+	wrapped := goerr.Wrapf(inner, "load user %d", 42)
+
+	if wrapped.Code() != goerr.CodeNotFound {
+		t.Errorf("expected code to be inherited, got '%s'", wrapped.Code())
+	}
+	if goerr.SeverityOf(wrapped) != goerr.SeverityCritical {
+		t.Errorf("expected severity to be inherited, got '%s'", goerr.SeverityOf(wrapped))
+	}
+	if !wrapped.Retryable() {
+		t.Error("expected retryable to be inherited")
+	}
+	if !wrapped.IsRuntime() {
+		t.Error("expected runtime classification to be inherited")
+	}
+
+	data, _ := json.Marshal(wrapped)
+	expected := `{"code":"NOT_FOUND","message":"load user 42: user 42 not found","retryable":true}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+
+	timeout := goerr.Wrap(fmt.Errorf("call billing: %w", goerr.WrapTimeout(errors.New("i/o timeout"))), "checkout")
+	if !timeout.Timeout() || !timeout.Temporary() {
+		t.Error("expected timeout to be inherited through the chain")
+	}
+
+	if inner.Code() != goerr.CodeNotFound || inner.Error() != "user 42 not found" {
+		t.Error("Wrap should not modify the inner error")
+	}
+}
+
+// loggerKey mimics the string based context keys of other packages
+type loggerKey string
+
+func TestWrapWithContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), goerr.RequestIDKey, "req-123")
+	ctx = context.WithValue(ctx, goerr.UserIDKey, "user-42")
+	orig := errors.New("timeout talking to billing")
+
+	done := make(chan *goerr.GoErr)
+	go func() {
+		done <- goerr.WrapWithContext(ctx, orig)
+	}()
+	err := <-done
+
+	values := err.Context()
+	if values["request_id"] != "req-123" || values["user_id"] != "user-42" {
+		t.Errorf("unexpected context values %v", values)
+	}
+	if !errors.Is(err, orig) || err.Error() != orig.Error() {
+		t.Errorf("expected the original error to be wrapped, got '%s'", err.Error())
+	}
+
+	values["request_id"] = "changed"
+	if err.Context()["request_id"] != "req-123" {
+		t.Error("expected Context to return a copy")
+	}
+
+	empty := goerr.WrapWithContext(context.Background(), orig)
+	if len(empty.Context()) != 0 {
+		t.Errorf("expected no context values, got %v", empty.Context())
+	}
+
+	if goerr.WrapWithContext(ctx, nil) != nil {
+		t.Error("expected nil for nil error")
+	}
+}
+
+func TestWrapWithContextKeys(t *testing.T) {
+	ctx := context.WithValue(context.Background(), loggerKey("request_id"), "req-123")
+	ctx = context.WithValue(ctx, loggerKey("tenant"), "acme")
+
+	err := goerr.WrapWithContext(ctx, errors.New("boom"), loggerKey("request_id"), loggerKey("tenant"))
+	values := err.Context()
+	if values["request_id"] != "req-123" || values["tenant"] != "acme" {
+		t.Errorf("unexpected context values %v", values)
+	}
+}
+
+func TestWrapWithContextInheritsAttributes(t *testing.T) {
+	ctx := context.WithValue(context.Background(), goerr.RequestIDKey, "req-123")
+	inner := goerr.WrapTimeout(errors.New("i/o timeout")).
+		WithCode(goerr.CodeUnavailable).
+		WithSeverity(goerr.SeverityCritical)
+
+	err := goerr.WrapWithContext(ctx, inner)
+	if err.Code() != goerr.CodeUnavailable || err.Severity() != goerr.SeverityCritical {
+		t.Errorf("expected code and severity to be inherited, got '%s' and '%s'", err.Code(), err.Severity())
+	}
+	if !err.Timeout() || !err.Retryable() || !err.IsRuntime() {
+		t.Error("expected timeout, retryable and runtime flags to be inherited")
+	}
+
+	outer := goerr.WrapWithContext(context.WithValue(context.Background(), goerr.UserIDKey, "user-42"), err)
+	if values := outer.Context(); values["request_id"] != "req-123" || values["user_id"] != "user-42" {
+		t.Errorf("expected context values to be merged, got %v", values)
+	}
+	if wrapped := goerr.Wrap(outer, "checkout"); wrapped.Context()["user_id"] != "user-42" {
+		t.Error("expected Wrap to keep the context values")
+	}
+}
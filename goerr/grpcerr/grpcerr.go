@@ -0,0 +1,85 @@
+// Package grpcerr maps goerr errors to gRPC status codes. It lives in its own
+// package so that only callers speaking gRPC depend on google.golang.org/grpc.
+package grpcerr
+
+import (
+	"context"
+	"errors"
+
+	"github.com/l00pss/helpme/goerr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var codeMapping = map[string]codes.Code{
+	goerr.CodeUnknown:          codes.Unknown,
+	goerr.CodeInvalidArgument:  codes.InvalidArgument,
+	goerr.CodeNotFound:         codes.NotFound,
+	goerr.CodeAlreadyExists:    codes.AlreadyExists,
+	goerr.CodePermissionDenied: codes.PermissionDenied,
+	goerr.CodeUnauthenticated:  codes.Unauthenticated,
+	goerr.CodeUnavailable:      codes.Unavailable,
+	goerr.CodeDeadlineExceeded: codes.DeadlineExceeded,
+	goerr.CodeCanceled:         codes.Canceled,
+	goerr.CodeInternal:         codes.Internal,
+}
+
+// GRPCCode returns the gRPC code for err. The first *goerr.GoErr with a code
+// in err's chain maps by its code, context errors map to Canceled and
+// DeadlineExceeded, uncoded runtime errors map to Internal and everything
+// else to Unknown.
+func GRPCCode(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+
+	if coded := codedGoErr(err); coded != nil {
+		if code, ok := codeMapping[coded.Code()]; ok {
+			return code
+		}
+	}
+
+	var goErr *goerr.GoErr
+	errors.As(err, &goErr)
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case goErr != nil && goErr.IsRuntime():
+		return codes.Internal
+	default:
+		return codes.Unknown
+	}
+}
+
+// codedGoErr returns the first *goerr.GoErr with a code in err's chain,
+// following wrapped errors depth first like errors.As
+func codedGoErr(err error) *goerr.GoErr {
+	if g, ok := err.(*goerr.GoErr); ok && g.Code() != "" {
+		return g
+	}
+
+	switch wrapped := err.(type) {
+	case interface{ Unwrap() error }:
+		if next := wrapped.Unwrap(); next != nil {
+			return codedGoErr(next)
+		}
+	case interface{ Unwrap() []error }:
+		for _, next := range wrapped.Unwrap() {
+			if coded := codedGoErr(next); coded != nil {
+				return coded
+			}
+		}
+	}
+	return nil
+}
+
+// ToGRPCStatus converts err into a gRPC status using GRPCCode
+func ToGRPCStatus(err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+	return status.New(GRPCCode(err), err.Error())
+}
@@ -0,0 +1,60 @@
+package grpcerr_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/l00pss/helpme/goerr"
+	"github.com/l00pss/helpme/goerr/grpcerr"
+	"google.golang.org/grpc/codes"
+)
+
+func TestGRPCCode(t *testing.T) {
+	base := errors.New("test error")
+
+	tests := []struct {
+		name     string
+		err      error
+		expected codes.Code
+	}{
+		{"nil error", nil, codes.OK},
+		{"not found", goerr.WrapNonRuntimeErr(base).WithCode(goerr.CodeNotFound), codes.NotFound},
+		{"invalid argument", goerr.WrapNonRuntimeErr(base).WithCode(goerr.CodeInvalidArgument), codes.InvalidArgument},
+		{"permission denied", goerr.WrapNonRuntimeErr(base).WithCode(goerr.CodePermissionDenied), codes.PermissionDenied},
+		{"wrapped coded error", fmt.Errorf("lookup: %w", goerr.WrapNonRuntimeErr(base).WithCode(goerr.CodeNotFound)), codes.NotFound},
+		{"goerr wrapped coded error", goerr.Wrap(goerr.WrapNonRuntimeErr(base).WithCode(goerr.CodeNotFound), "lookup"), codes.NotFound},
+		{"uncoded outer GoErr", goerr.WrapRuntimeErr(fmt.Errorf("lookup: %w", goerr.WrapNonRuntimeErr(base).WithCode(goerr.CodeNotFound))), codes.NotFound},
+		{"joined errors", errors.Join(base, goerr.WrapNonRuntimeErr(base).WithCode(goerr.CodeAlreadyExists)), codes.AlreadyExists},
+		{"uncoded runtime error", goerr.WrapRuntimeErr(base), codes.Internal},
+		{"uncoded non-runtime error", goerr.WrapNonRuntimeErr(base), codes.Unknown},
+		{"context canceled", context.Canceled, codes.Canceled},
+		{"deadline exceeded", goerr.WrapRuntimeErr(context.DeadlineExceeded), codes.DeadlineExceeded},
+		{"plain error", base, codes.Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := grpcerr.GRPCCode(tt.err); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestToGRPCStatus(t *testing.T) {
+	err := goerr.WrapNonRuntimeErr(errors.New("user not found")).WithCode(goerr.CodeNotFound)
+
+	st := grpcerr.ToGRPCStatus(err)
+	if st.Code() != codes.NotFound {
+		t.Errorf("expected %v, got %v", codes.NotFound, st.Code())
+	}
+	if st.Message() != "user not found" {
+		t.Errorf("expected 'user not found', got '%s'", st.Message())
+	}
+
+	if grpcerr.ToGRPCStatus(nil).Code() != codes.OK {
+		t.Error("expected OK status for nil error")
+	}
+}
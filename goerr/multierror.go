@@ -0,0 +1,43 @@
+package goerr
+
+import (
+	"slices"
+	"strings"
+)
+
+// MultiError accumulates several errors into one
+type MultiError struct {
+	errs []error
+}
+
+// Add appends err to the accumulated errors, nil errors are ignored
+func (m *MultiError) Add(err error) {
+	if err != nil {
+		m.errs = append(m.errs, err)
+	}
+}
+
+func (m *MultiError) HasErrors() bool {
+	return len(m.errs) > 0
+}
+
+// ErrorOrNil returns nil if no errors were added, otherwise a non-runtime GoErr
+// wrapping a snapshot of m, so later calls to Add do not change it
+func (m *MultiError) ErrorOrNil() error {
+	if !m.HasErrors() {
+		return nil
+	}
+	return WrapNonRuntimeErr(&MultiError{errs: slices.Clone(m.errs)})
+}
+
+func (m *MultiError) Error() string {
+	msgs := make([]string, len(m.errs))
+	for i, err := range m.errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (m *MultiError) Unwrap() []error {
+	return m.errs
+}
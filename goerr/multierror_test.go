@@ -0,0 +1,67 @@
+package goerr_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/l00pss/helpme/goerr"
+)
+
+func TestMultiError(t *testing.T) {
+	nameErr := errors.New("name is required")
+	ageErr := errors.New("age must be positive")
+
+	var multi goerr.MultiError
+	if multi.HasErrors() {
+		t.Error("expected no errors")
+	}
+	if multi.ErrorOrNil() != nil {
+		t.Error("expected ErrorOrNil to return nil without errors")
+	}
+
+	multi.Add(nameErr)
+	multi.Add(nil)
+	multi.Add(ageErr)
+
+	if !multi.HasErrors() {
+		t.Error("expected errors")
+	}
+	if multi.Error() != "name is required; age must be positive" {
+		t.Errorf("unexpected message '%s'", multi.Error())
+	}
+
+	unwrapped := multi.Unwrap()
+	if len(unwrapped) != 2 || unwrapped[0] != nameErr || unwrapped[1] != ageErr {
+		t.Errorf("unexpected unwrapped errors %v", unwrapped)
+	}
+
+	err := multi.ErrorOrNil()
+	var goErr *goerr.GoErr
+	if !errors.As(err, &goErr) {
+		t.Fatal("expected ErrorOrNil to return a GoErr")
+	}
+	if goErr.IsRuntime() {
+		t.Error("expected non-runtime error")
+	}
+	if err.Error() != multi.Error() {
+		t.Errorf("expected '%s', got '%s'", multi.Error(), err.Error())
+	}
+	if !errors.Is(err, nameErr) || !errors.Is(err, ageErr) {
+		t.Error("expected errors.Is to match each accumulated error")
+	}
+}
+
+func TestMultiErrorOrNilSnapshot(t *testing.T) {
+	var multi goerr.MultiError
+	multi.Add(errors.New("a"))
+
+	err := multi.ErrorOrNil()
+	multi.Add(errors.New("b"))
+
+	if err.Error() != "a" {
+		t.Errorf("expected later Add calls not to change the returned error, got '%s'", err.Error())
+	}
+	if multi.Error() != "a; b" {
+		t.Errorf("unexpected message '%s'", multi.Error())
+	}
+}
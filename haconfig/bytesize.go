@@ -0,0 +1,89 @@
+package haconfig
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ByteSize represents a size in bytes parsed from human readable units like "10MB" or "512KiB"
+type ByteSize int64
+
+// Byte size units
+const (
+	Byte ByteSize = 1
+
+	KB ByteSize = 1000
+	MB          = 1000 * KB
+	GB          = 1000 * MB
+	TB          = 1000 * GB
+
+	KiB ByteSize = 1024
+	MiB          = 1024 * KiB
+	GiB          = 1024 * MiB
+	TiB          = 1024 * GiB
+)
+
+// byteSizeUnits maps lowercase unit suffixes to their size in bytes
+var byteSizeUnits = map[string]ByteSize{
+	"":    Byte,
+	"b":   Byte,
+	"kb":  KB,
+	"mb":  MB,
+	"gb":  GB,
+	"tb":  TB,
+	"kib": KiB,
+	"mib": MiB,
+	"gib": GiB,
+	"tib": TiB,
+}
+
+// ParseByteSize parses a human readable size such as "10MB", "1GiB" or "2048"
+func ParseByteSize(value string) (ByteSize, error) {
+	value = strings.TrimSpace(value)
+
+	// Split the numeric part from the unit suffix
+	i := 0
+	for i < len(value) && (value[i] >= '0' && value[i] <= '9' || value[i] == '.') {
+		i++
+	}
+	number, unit := value[:i], strings.TrimSpace(value[i:])
+	if number == "" {
+		return 0, fmt.Errorf("invalid byte size: %s", value)
+	}
+
+	multiplier, ok := byteSizeUnits[strings.ToLower(unit)]
+	if !ok {
+		return 0, fmt.Errorf("unknown byte size unit %q in %s", unit, value)
+	}
+
+	if intVal, err := strconv.ParseInt(number, 10, 64); err == nil {
+		return ByteSize(intVal) * multiplier, nil
+	}
+
+	floatVal, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid byte size: %s", value)
+	}
+	return ByteSize(floatVal * float64(multiplier)), nil
+}
+
+// UnmarshalYAML parses a byte size from a YAML scalar
+func (b *ByteSize) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var value string
+	if err := unmarshal(&value); err != nil {
+		return err
+	}
+
+	size, err := ParseByteSize(value)
+	if err != nil {
+		return err
+	}
+	*b = size
+	return nil
+}
+
+// Int64 returns the size in bytes
+func (b ByteSize) Int64() int64 {
+	return int64(b)
+}
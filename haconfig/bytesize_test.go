@@ -0,0 +1,111 @@
+package haconfig
+
+import (
+	"os"
+	"testing"
+)
+
+type UploadConfig struct {
+	MaxUploadSize ByteSize `yaml:"max_upload_size"`
+	CacheSize     ByteSize `yaml:"cache_size"`
+}
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected ByteSize
+		wantErr  bool
+	}{
+		{"2048", 2048, false},
+		{"10MB", 10 * MB, false},
+		{"512KiB", 512 * KiB, false},
+		{"1GiB", 1 << 30, false},
+		{"1.5KB", 1500, false},
+		{"10 mb", 10 * MB, false},
+		{"10XB", 0, true},
+		{"MB", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := ParseByteSize(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseByteSize(%q) expected error", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseByteSize(%q) unexpected error: %v", tt.input, err)
+			}
+			if result != tt.expected {
+				t.Errorf("ParseByteSize(%q) = %d, want %d", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestByteSizeFromEnv(t *testing.T) {
+	os.Setenv("MAX_UPLOAD_SIZE", "10MB")
+	os.Setenv("CACHE_SIZE", "1GiB")
+	defer os.Unsetenv("MAX_UPLOAD_SIZE")
+	defer os.Unsetenv("CACHE_SIZE")
+
+	config := New()
+	var cfg UploadConfig
+
+	err := config.Load(&cfg)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if cfg.MaxUploadSize != 10_000_000 {
+		t.Errorf("Expected max upload size 10000000, got %d", cfg.MaxUploadSize)
+	}
+	if cfg.CacheSize != 1073741824 {
+		t.Errorf("Expected cache size 1073741824, got %d", cfg.CacheSize)
+	}
+}
+
+func TestByteSizeInvalidUnit(t *testing.T) {
+	os.Setenv("MAX_UPLOAD_SIZE", "10XB")
+	defer os.Unsetenv("MAX_UPLOAD_SIZE")
+
+	config := New()
+	var cfg UploadConfig
+
+	if err := config.Load(&cfg); err == nil {
+		t.Error("Expected error for unknown byte size unit")
+	}
+}
+
+func TestByteSizeFromYAML(t *testing.T) {
+	yamlContent := `
+max_upload_size: 10MB
+cache_size: 512KiB
+`
+
+	tmpFile, err := os.CreateTemp("", "config*.yaml")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.WriteString(yamlContent); err != nil {
+		t.Fatalf("Failed to write YAML content: %v", err)
+	}
+	tmpFile.Close()
+
+	var cfg UploadConfig
+	err = LoadFromFile(tmpFile.Name(), &cfg)
+	if err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+
+	if cfg.MaxUploadSize != 10*MB {
+		t.Errorf("Expected max upload size %d, got %d", 10*MB, cfg.MaxUploadSize)
+	}
+	if cfg.CacheSize != 512*KiB {
+		t.Errorf("Expected cache size %d, got %d", 512*KiB, cfg.CacheSize)
+	}
+}
@@ -1,25 +1,52 @@
 package haconfig
 
 import (
+	"bytes"
+	"context"
+	"flag"
 	"fmt"
+	"io"
 	"os"
 	"reflect"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
+// Precedence controls which source wins when both YAML and env set a field
+type Precedence int
+
+const (
+	// EnvOverFile lets environment variables override YAML values (default)
+	EnvOverFile Precedence = iota
+	// FileOverEnv lets YAML values override environment variables
+	FileOverEnv
+)
+
 // Config represents the configuration manager
 type Config struct {
 	data       interface{}
 	envPrefix  string
 	yamlFile   string
+	yamlFiles  []string
 	envMapping map[string]string
+	precedence Precedence
+	strict     bool
+	decoders   map[reflect.Type]DecoderFunc
+	flags      *flag.FlagSet
+	onAlias    func(envName, alias string)
+	dotEnvFile string
+	dotEnvWins bool
 }
 
+// DecoderFunc parses a raw environment value into a value of a custom type
+type DecoderFunc func(string) (interface{}, error)
+
 // ConfigOption represents configuration options
 type ConfigOption func(*Config)
 
@@ -37,6 +64,13 @@ func WithYAMLFile(file string) ConfigOption {
 	}
 }
 
+// WithYAMLFiles sets multiple YAML files loaded in order, later files override earlier ones
+func WithYAMLFiles(files ...string) ConfigOption {
+	return func(c *Config) {
+		c.yamlFiles = append(c.yamlFiles, files...)
+	}
+}
+
 // WithEnvMapping allows custom environment variable mappings
 func WithEnvMapping(mapping map[string]string) ConfigOption {
 	return func(c *Config) {
@@ -44,6 +78,64 @@ func WithEnvMapping(mapping map[string]string) ConfigOption {
 	}
 }
 
+// WithDecoder registers a decoder for values of type t, consulted before the built-in parsing
+func WithDecoder(t reflect.Type, fn DecoderFunc) ConfigOption {
+	return func(c *Config) {
+		if c.decoders == nil {
+			c.decoders = make(map[reflect.Type]DecoderFunc)
+		}
+		c.decoders[t] = fn
+	}
+}
+
+// WithStrict makes loading fail on YAML keys that don't match any field
+func WithStrict() ConfigOption {
+	return func(c *Config) {
+		c.strict = true
+	}
+}
+
+// WithPrecedence sets which source wins when both YAML and env set a field
+func WithPrecedence(precedence Precedence) ConfigOption {
+	return func(c *Config) {
+		c.precedence = precedence
+	}
+}
+
+// WithFlags uses explicitly set flags from fs as a source that overrides both
+// env and YAML. A flag matches a field when its name, with "-" or "." in place
+// of "_", equals the env name without the env prefix, e.g. "server-host".
+// fs must be parsed before Load is called.
+func WithFlags(fs *flag.FlagSet) ConfigOption {
+	return func(c *Config) {
+		c.flags = fs
+	}
+}
+
+// WithAliasHook registers a function called when a field is loaded from an
+// envalias fallback instead of its primary env name, e.g. to log a deprecation
+func WithAliasHook(fn func(envName, alias string)) ConfigOption {
+	return func(c *Config) {
+		c.onAlias = fn
+	}
+}
+
+// WithDotEnv reads KEY=VALUE lines from a dotenv file as environment variables.
+// Variables already set in the process environment take precedence unless
+// WithDotEnvOverride is also given. A missing file is ignored.
+func WithDotEnv(path string) ConfigOption {
+	return func(c *Config) {
+		c.dotEnvFile = path
+	}
+}
+
+// WithDotEnvOverride lets values from the dotenv file win over the process environment
+func WithDotEnvOverride() ConfigOption {
+	return func(c *Config) {
+		c.dotEnvWins = true
+	}
+}
+
 // New creates a new configuration manager
 func New(opts ...ConfigOption) *Config {
 	config := &Config{
@@ -60,6 +152,12 @@ func New(opts ...ConfigOption) *Config {
 
 // Load loads configuration into the provided struct
 func (c *Config) Load(cfg interface{}) error {
+	return c.LoadContext(context.Background(), cfg)
+}
+
+// LoadContext loads configuration like Load, returning ctx.Err() if ctx is
+// cancelled before or while the sources are read
+func (c *Config) LoadContext(ctx context.Context, cfg interface{}) error {
 	if cfg == nil {
 		return fmt.Errorf("config cannot be nil")
 	}
@@ -69,49 +167,306 @@ func (c *Config) Load(cfg interface{}) error {
 		return fmt.Errorf("config must be a pointer to struct")
 	}
 
-	// First load from YAML file if specified
-	if c.yamlFile != "" {
-		if err := c.loadFromYAML(cfg); err != nil {
+	return c.load(ctx, v, nil)
+}
+
+// sourceLoader loads the values of one source into a config
+type sourceLoader struct {
+	source Source
+	load   func(context.Context, interface{}) error
+}
+
+// load applies every source to the struct v points to. If trace is not nil,
+// it records the source that last changed each field.
+func (c *Config) load(ctx context.Context, v reflect.Value, trace map[string]Source) error {
+	// Sources are applied in order, so the last one wins
+	yamlSource := sourceLoader{SourceYAML, c.loadYAMLSource}
+	envSource := sourceLoader{SourceEnv, c.loadEnvSource}
+	sources := []sourceLoader{yamlSource, envSource}
+	if c.precedence == FileOverEnv {
+		sources = []sourceLoader{envSource, yamlSource}
+	}
+	if c.flags != nil {
+		sources = append(sources, sourceLoader{SourceFlag, c.loadFlagSource})
+	}
+
+	var before map[string]interface{}
+	if trace != nil {
+		before = snapshotFields(v.Elem(), "")
+	}
+
+	for _, src := range sources {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := src.load(ctx, v.Interface()); err != nil {
+			return err
+		}
+		if trace != nil {
+			before = traceChanges(before, snapshotFields(v.Elem(), ""), src.source, trace)
+		}
+	}
+
+	return c.checkValues(v.Elem())
+}
+
+// checkValues runs the checks every loader applies to the loaded struct v
+func (c *Config) checkValues(v reflect.Value) error {
+	if err := c.checkEnums(v, v.Type(), ""); err != nil {
+		return err
+	}
+	return c.checkRanges(v, v.Type(), "")
+}
+
+// Enum is implemented by string types that only allow a fixed set of values
+type Enum interface {
+	Values() []string
+}
+
+var enumType = reflect.TypeOf((*Enum)(nil)).Elem()
+
+// asEnum returns the string field as an Enum, also when Values has a pointer receiver
+func asEnum(field reflect.Value) (Enum, bool) {
+	if field.Kind() != reflect.String {
+		return nil, false
+	}
+	if enum, ok := field.Interface().(Enum); ok {
+		return enum, true
+	}
+	if field.CanAddr() {
+		if enum, ok := field.Addr().Interface().(Enum); ok {
+			return enum, true
+		}
+	}
+	return nil, false
+}
+
+// checkEnums verifies that every non-empty Enum field holds one of its allowed values
+func (c *Config) checkEnums(v reflect.Value, t reflect.Type, prefix string) error {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		fieldType := t.Field(i)
+
+		if !fieldType.IsExported() {
+			continue
+		}
+
+		fieldPath := c.buildFieldPath(prefix, fieldType.Name)
+
+		if enum, ok := asEnum(field); ok {
+			value := field.String()
+			allowed := enum.Values()
+			if value != "" && !slices.Contains(allowed, value) {
+				return fmt.Errorf("invalid value %q for %s: must be one of %s", value, fieldPath, strings.Join(allowed, ", "))
+			}
+			continue
+		}
+
+		if field.Kind() == reflect.Ptr && !field.IsNil() && field.Elem().Kind() == reflect.Struct {
+			field = field.Elem()
+		}
+		if field.Kind() == reflect.Struct {
+			if err := c.checkEnums(field, field.Type(), fieldPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// loadYAMLSource loads from YAML files if specified. The files are deep
+// merged before decoding, so a later file only overrides the keys it sets,
+// including keys of nested maps.
+func (c *Config) loadYAMLSource(ctx context.Context, cfg interface{}) error {
+	var merged *yaml.Node
+	for _, file := range c.yamlPaths() {
+		node, err := c.readYAMLNode(ctx, file, reflect.TypeOf(cfg))
+		if err != nil {
 			return fmt.Errorf("failed to load YAML: %w", err)
 		}
+		merged = mergeNodes(merged, node)
+	}
+
+	if merged == nil {
+		return nil
+	}
+	if err := merged.Decode(cfg); err != nil {
+		return fmt.Errorf("failed to load YAML: %w", err)
 	}
+	return nil
+}
 
-	// Then override with environment variables
-	if err := c.loadFromEnv(cfg); err != nil {
+// yamlPaths returns the YAML files to load in order
+func (c *Config) yamlPaths() []string {
+	var paths []string
+	if c.yamlFile != "" {
+		paths = append(paths, c.yamlFile)
+	}
+	return append(paths, c.yamlFiles...)
+}
+
+// loadEnvSource loads from environment variables
+func (c *Config) loadEnvSource(ctx context.Context, cfg interface{}) error {
+	if err := c.loadFromEnv(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to load from env: %w", err)
 	}
+	return nil
+}
+
+// loadFlagSource loads from explicitly set flags
+func (c *Config) loadFlagSource(_ context.Context, cfg interface{}) error {
+	values := make(map[string]string)
+	c.flags.Visit(func(f *flag.Flag) {
+		name := strings.NewReplacer("-", "_", ".", "_").Replace(f.Name)
+		values[strings.ToLower(name)] = f.Value.String()
+	})
+
+	envPrefix := ""
+	if c.envPrefix != "" {
+		envPrefix = strings.ToUpper(c.envPrefix) + "_"
+	}
+	lookup := func(envName string) string {
+		return values[strings.ToLower(strings.TrimPrefix(envName, envPrefix))]
+	}
 
+	v := reflect.ValueOf(cfg).Elem()
+	if err := c.processStruct(v, v.Type(), "", lookup); err != nil {
+		return fmt.Errorf("failed to load from flags: %w", err)
+	}
 	return nil
 }
 
-// loadFromYAML loads configuration from YAML file
-func (c *Config) loadFromYAML(cfg interface{}) error {
-	data, err := os.ReadFile(c.yamlFile)
+// readYAMLMap reads a YAML file into a map. A missing file yields a nil map.
+func readYAMLMap(ctx context.Context, file string) (map[string]any, error) {
+	data, err := readFileContext(ctx, file)
 	if err != nil {
 		if os.IsNotExist(err) {
 			// File doesn't exist, skip YAML loading
-			return nil
+			return nil, nil
 		}
-		return fmt.Errorf("failed to read YAML file: %w", err)
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to read YAML file: %w", err)
 	}
 
-	if err := yaml.Unmarshal(data, cfg); err != nil {
-		return fmt.Errorf("failed to unmarshal YAML: %w", err)
+	var values map[string]any
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&values); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to unmarshal YAML: %w", err)
 	}
+	return values, nil
+}
 
-	return nil
+// readYAMLNode parses a YAML file into its root node. A missing or empty
+// file yields a nil node. In strict mode the file is also checked against
+// cfgType on its own, so unknown keys are reported with the file's own lines.
+func (c *Config) readYAMLNode(ctx context.Context, file string, cfgType reflect.Type) (*yaml.Node, error) {
+	data, err := readFileContext(ctx, file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// File doesn't exist, skip YAML loading
+			return nil, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to read YAML file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&doc); err != nil {
+		if err == io.EOF {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to unmarshal YAML %s: %w", file, err)
+	}
+
+	if c.strict {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(reflect.New(cfgType.Elem()).Interface()); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal YAML %s: %w", file, err)
+		}
+	}
+
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	return doc.Content[0], nil
+}
+
+// mergeNodes deep merges src into dst, with src values winning. Mappings are
+// merged key by key; any other node in src replaces the one in dst. Scalars
+// are kept as parsed, so values decode exactly as written in their file.
+func mergeNodes(dst, src *yaml.Node) *yaml.Node {
+	if dst == nil {
+		return src
+	}
+	if src == nil {
+		return dst
+	}
+	if dst.Kind != yaml.MappingNode || src.Kind != yaml.MappingNode {
+		return src
+	}
+
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key, value := src.Content[i], src.Content[i+1]
+		found := false
+		for j := 0; j+1 < len(dst.Content); j += 2 {
+			if dst.Content[j].Value == key.Value {
+				dst.Content[j+1] = mergeNodes(dst.Content[j+1], value)
+				found = true
+				break
+			}
+		}
+		if !found {
+			dst.Content = append(dst.Content, key, value)
+		}
+	}
+	return dst
+}
+
+// readFileContext reads a file, stopping with ctx.Err() if ctx is cancelled mid-read
+func readFileContext(ctx context.Context, file string) ([]byte, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return io.ReadAll(&contextReader{ctx: ctx, r: f})
+}
+
+// contextReader checks for cancellation before every read
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
 }
 
-// loadFromEnv loads configuration from environment variables
-func (c *Config) loadFromEnv(cfg interface{}) error {
+// Read reads from the underlying reader unless the context is done
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
+
+// loadFromEnv loads configuration from environment variables and the dotenv file
+func (c *Config) loadFromEnv(ctx context.Context, cfg interface{}) error {
+	lookup, err := c.envLookup(ctx)
+	if err != nil {
+		return err
+	}
+
 	v := reflect.ValueOf(cfg).Elem()
 	t := v.Type()
 
-	return c.processStruct(v, t, "")
+	return c.processStruct(v, t, "", lookup)
 }
 
-// processStruct processes a struct and its nested fields
-func (c *Config) processStruct(v reflect.Value, t reflect.Type, prefix string) error {
+// processStruct processes a struct and its nested fields, reading values by env name through lookup
+func (c *Config) processStruct(v reflect.Value, t reflect.Type, prefix string, lookup func(string) string) error {
 	for i := 0; i < v.NumField(); i++ {
 		field := v.Field(i)
 		fieldType := t.Field(i)
@@ -125,23 +480,32 @@ func (c *Config) processStruct(v reflect.Value, t reflect.Type, prefix string) e
 		fieldName := fieldType.Name
 
 		// Handle nested structs
-		if field.Kind() == reflect.Struct && fieldType.Type != reflect.TypeOf(time.Time{}) {
-			newPrefix := c.buildPrefix(prefix, fieldName)
-			if err := c.processStruct(field, fieldType.Type, newPrefix); err != nil {
+		if c.isNestedStruct(fieldType.Type) {
+			newPrefix := c.nestedPrefix(prefix, fieldType)
+			if err := c.processStruct(field, fieldType.Type, newPrefix, lookup); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Handle slices of structs, indexed by position
+		if c.isNestedStructSlice(fieldType.Type) {
+			newPrefix := c.nestedPrefix(prefix, fieldType)
+			if err := c.processStructSlice(field, newPrefix, lookup); err != nil {
 				return err
 			}
 			continue
 		}
 
 		// Handle pointers to structs
-		if field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.Struct {
+		if c.isNestedStructPtr(fieldType.Type) {
 			// Check if any env var exists for this nested struct before creating it
-			newPrefix := c.buildPrefix(prefix, fieldName)
-			if c.hasAnyEnvVar(field.Type().Elem(), newPrefix) {
+			newPrefix := c.nestedPrefix(prefix, fieldType)
+			if c.hasAnyEnvVar(field.Type().Elem(), newPrefix, lookup) {
 				if field.IsNil() {
 					field.Set(reflect.New(field.Type().Elem()))
 				}
-				if err := c.processStruct(field.Elem(), field.Type().Elem(), newPrefix); err != nil {
+				if err := c.processStruct(field.Elem(), field.Type().Elem(), newPrefix, lookup); err != nil {
 					return err
 				}
 			}
@@ -155,8 +519,13 @@ func (c *Config) processStruct(v reflect.Value, t reflect.Type, prefix string) e
 			envName = customName
 		}
 
+		value, alias := c.lookupWithAliases(fieldType, envName, lookup)
+		if alias != "" && c.onAlias != nil {
+			c.onAlias(envName, alias)
+		}
+
 		// Set value from environment variable
-		if err := c.setFieldFromEnv(field, envName); err != nil {
+		if err := c.setFieldFromEnv(field, value); err != nil {
 			return fmt.Errorf("failed to set field %s: %w", fieldName, err)
 		}
 	}
@@ -164,21 +533,45 @@ func (c *Config) processStruct(v reflect.Value, t reflect.Type, prefix string) e
 	return nil
 }
 
+// processStructSlice processes each element of a slice of structs with the
+// element index added to prefix, e.g. SERVERS_0_HOST. Env vars for the index
+// right after the last element append a new element, so env can extend the slice.
+func (c *Config) processStructSlice(field reflect.Value, prefix string, lookup func(string) string) error {
+	elemType := field.Type().Elem()
+	for i := 0; ; i++ {
+		elemPrefix := prefix + "_" + strconv.Itoa(i)
+		if i >= field.Len() {
+			if !c.hasAnyEnvVar(elemType, elemPrefix, lookup) {
+				return nil
+			}
+			field.Set(reflect.Append(field, reflect.Zero(elemType)))
+		}
+		if err := c.processStruct(field.Index(i), elemType, elemPrefix, lookup); err != nil {
+			return err
+		}
+	}
+}
+
 // hasAnyEnvVar checks if any environment variable exists for a struct type
-func (c *Config) hasAnyEnvVar(structType reflect.Type, prefix string) bool {
+func (c *Config) hasAnyEnvVar(structType reflect.Type, prefix string, lookup func(string) string) bool {
 	for i := 0; i < structType.NumField(); i++ {
 		fieldType := structType.Field(i)
 		fieldName := fieldType.Name
 
 		// Check nested structs recursively
-		if fieldType.Type.Kind() == reflect.Struct && fieldType.Type != reflect.TypeOf(time.Time{}) {
-			newPrefix := c.buildPrefix(prefix, fieldName)
-			if c.hasAnyEnvVar(fieldType.Type, newPrefix) {
+		if c.isNestedStruct(fieldType.Type) {
+			newPrefix := c.nestedPrefix(prefix, fieldType)
+			if c.hasAnyEnvVar(fieldType.Type, newPrefix, lookup) {
+				return true
+			}
+		} else if c.isNestedStructPtr(fieldType.Type) {
+			newPrefix := c.nestedPrefix(prefix, fieldType)
+			if c.hasAnyEnvVar(fieldType.Type.Elem(), newPrefix, lookup) {
 				return true
 			}
-		} else if fieldType.Type.Kind() == reflect.Ptr && fieldType.Type.Elem().Kind() == reflect.Struct {
-			newPrefix := c.buildPrefix(prefix, fieldName)
-			if c.hasAnyEnvVar(fieldType.Type.Elem(), newPrefix) {
+		} else if c.isNestedStructSlice(fieldType.Type) {
+			newPrefix := c.nestedPrefix(prefix, fieldType) + "_0"
+			if c.hasAnyEnvVar(fieldType.Type.Elem(), newPrefix, lookup) {
 				return true
 			}
 		} else {
@@ -186,7 +579,7 @@ func (c *Config) hasAnyEnvVar(structType reflect.Type, prefix string) bool {
 			if customName, exists := c.envMapping[fieldName]; exists {
 				envName = customName
 			}
-			if os.Getenv(envName) != "" {
+			if value, _ := c.lookupWithAliases(fieldType, envName, lookup); value != "" {
 				return true
 			}
 		}
@@ -194,6 +587,53 @@ func (c *Config) hasAnyEnvVar(structType reflect.Type, prefix string) bool {
 	return false
 }
 
+// lookupWithAliases returns the value for envName, falling back to the names in
+// the envalias tag in order. The alias that provided the value is also returned.
+func (c *Config) lookupWithAliases(fieldType reflect.StructField, envName string, lookup func(string) string) (string, string) {
+	if value := lookup(envName); value != "" {
+		return value, ""
+	}
+
+	tag := fieldType.Tag.Get("envalias")
+	if tag == "" {
+		return "", ""
+	}
+	for _, alias := range strings.Split(tag, ",") {
+		alias = strings.TrimSpace(alias)
+		if value := lookup(alias); value != "" {
+			return value, alias
+		}
+	}
+	return "", ""
+}
+
+// isNestedStruct checks if a type is a struct whose fields are loaded individually
+func (c *Config) isNestedStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || t == reflect.TypeOf(time.Time{}) {
+		return false
+	}
+	_, hasDecoder := c.decoders[t]
+	return !hasDecoder
+}
+
+// isNestedStructSlice checks if a type is a slice of nested structs
+func (c *Config) isNestedStructSlice(t reflect.Type) bool {
+	if t.Kind() != reflect.Slice {
+		return false
+	}
+	_, hasDecoder := c.decoders[t]
+	return !hasDecoder && c.isNestedStruct(t.Elem())
+}
+
+// isNestedStructPtr checks if a type is a pointer to a nested struct
+func (c *Config) isNestedStructPtr(t reflect.Type) bool {
+	if t.Kind() != reflect.Ptr {
+		return false
+	}
+	_, hasDecoder := c.decoders[t]
+	return !hasDecoder && c.isNestedStruct(t.Elem())
+}
+
 // getEnvName generates environment variable name
 func (c *Config) getEnvName(fieldName, prefix string) string {
 	envName := c.toSnakeCase(fieldName)
@@ -218,6 +658,26 @@ func (c *Config) buildPrefix(currentPrefix, fieldName string) string {
 	return currentPrefix + "_" + snakeName
 }
 
+// nestedPrefix builds prefix for a nested struct field, honoring the prefix tag.
+// A tag of "-" keeps the current prefix, any other value replaces the field name.
+// Embedded structs keep the current prefix unless tagged, like promoted fields.
+func (c *Config) nestedPrefix(currentPrefix string, fieldType reflect.StructField) string {
+	tag, ok := fieldType.Tag.Lookup("prefix")
+	if !ok || tag == "" {
+		if fieldType.Anonymous {
+			return currentPrefix
+		}
+		return c.buildPrefix(currentPrefix, fieldType.Name)
+	}
+	if tag == "-" {
+		return currentPrefix
+	}
+	if currentPrefix == "" {
+		return tag
+	}
+	return currentPrefix + "_" + tag
+}
+
 // toSnakeCase converts CamelCase to snake_case using regex
 func (c *Config) toSnakeCase(str string) string {
 	if str == "" {
@@ -235,9 +695,8 @@ func (c *Config) toSnakeCase(str string) string {
 	return strings.ToLower(result)
 }
 
-// setFieldFromEnv sets field value from environment variable
-func (c *Config) setFieldFromEnv(field reflect.Value, envName string) error {
-	envValue := os.Getenv(envName)
+// setFieldFromEnv sets field value from an environment variable value
+func (c *Config) setFieldFromEnv(field reflect.Value, envValue string) error {
 	if envValue == "" {
 		return nil // No environment variable set
 	}
@@ -247,14 +706,18 @@ func (c *Config) setFieldFromEnv(field reflect.Value, envName string) error {
 
 // setFieldValue sets field value based on its type
 func (c *Config) setFieldValue(field reflect.Value, value string) error {
+	if decoder, exists := c.decoders[field.Type()]; exists {
+		return c.setDecodedValue(field, decoder, value)
+	}
+
 	switch field.Kind() {
 	case reflect.String:
 		field.SetString(value)
 
 	case reflect.Bool:
-		boolVal, err := strconv.ParseBool(value)
+		boolVal, err := parseBool(value)
 		if err != nil {
-			return fmt.Errorf("invalid bool value: %s", value)
+			return err
 		}
 		field.SetBool(boolVal)
 
@@ -268,6 +731,15 @@ func (c *Config) setFieldValue(field reflect.Value, value string) error {
 			return nil
 		}
 
+		if field.Type() == reflect.TypeOf(ByteSize(0)) {
+			size, err := ParseByteSize(value)
+			if err != nil {
+				return err
+			}
+			field.SetInt(int64(size))
+			return nil
+		}
+
 		intVal, err := strconv.ParseInt(value, 10, 64)
 		if err != nil {
 			return fmt.Errorf("invalid int value: %s", value)
@@ -326,6 +798,37 @@ func (c *Config) setFieldValue(field reflect.Value, value string) error {
 	return nil
 }
 
+// parseBool accepts the strconv.ParseBool values plus yes/no and on/off, case-insensitively
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "yes", "on":
+		return true, nil
+	case "no", "off":
+		return false, nil
+	}
+	boolVal, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		return false, fmt.Errorf("invalid bool value %q: must be true/false, yes/no, on/off or 1/0", value)
+	}
+	return boolVal, nil
+}
+
+// setDecodedValue sets field value using a registered decoder
+func (c *Config) setDecodedValue(field reflect.Value, decoder DecoderFunc, value string) error {
+	decoded, err := decoder(value)
+	if err != nil {
+		return fmt.Errorf("invalid %v value: %w", field.Type(), err)
+	}
+
+	decodedValue := reflect.ValueOf(decoded)
+	if !decodedValue.IsValid() || !decodedValue.Type().AssignableTo(field.Type()) {
+		return fmt.Errorf("decoder for %v returned %T", field.Type(), decoded)
+	}
+
+	field.Set(decodedValue)
+	return nil
+}
+
 // setSliceValue sets slice value from comma-separated string
 func (c *Config) setSliceValue(field reflect.Value, value string) error {
 	parts := strings.Split(value, ",")
@@ -352,8 +855,48 @@ func LoadFromFile(file string, cfg interface{}, opts ...ConfigOption) error {
 
 // LoadFromEnv loads configuration only from environment variables
 func LoadFromEnv(cfg interface{}, opts ...ConfigOption) error {
+	v := reflect.ValueOf(cfg)
+	if cfg == nil || v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config must be a pointer to struct")
+	}
+
 	config := New(opts...)
-	return config.loadFromEnv(cfg)
+	if err := config.loadFromEnv(context.Background(), cfg); err != nil {
+		return err
+	}
+	return config.checkValues(v.Elem())
+}
+
+// Load creates a configuration manager with opts, then loads and validates a T
+func Load[T any](opts ...ConfigOption) (T, error) {
+	var cfg T
+	config := New(opts...)
+	if err := config.Load(&cfg); err != nil {
+		var zero T
+		return zero, err
+	}
+	if err := config.Validate(&cfg); err != nil {
+		var zero T
+		return zero, err
+	}
+	return cfg, nil
+}
+
+// Once returns a function that loads a T with Load on its first call and
+// returns the same result and error on every later call. It is safe for
+// concurrent use.
+func Once[T any](opts ...ConfigOption) func() (T, error) {
+	var (
+		once sync.Once
+		cfg  T
+		err  error
+	)
+	return func() (T, error) {
+		once.Do(func() {
+			cfg, err = Load[T](opts...)
+		})
+		return cfg, err
+	}
 }
 
 // MustLoad loads configuration and panics on error
@@ -387,6 +930,14 @@ func (c *Config) validateStruct(v reflect.Value, t reflect.Type, prefix string)
 			}
 		}
 
+		// Check validate tag
+		if tag := fieldType.Tag.Get("validate"); tag != "" {
+			fieldPath := c.buildFieldPath(prefix, fieldType.Name)
+			if err := c.checkRange(field, tag, fieldPath); err != nil {
+				return err
+			}
+		}
+
 		// Validate nested structs
 		if field.Kind() == reflect.Struct && fieldType.Type != reflect.TypeOf(time.Time{}) {
 			newPrefix := c.buildFieldPath(prefix, fieldType.Name)
@@ -1,7 +1,17 @@
 package haconfig
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/url"
 	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -267,6 +277,248 @@ database:
 	}
 }
 
+func TestPrecedence(t *testing.T) {
+	yamlContent := `
+server:
+  host: yaml-host
+  port: 3000
+`
+
+	tmpFile, err := os.CreateTemp("", "config*.yaml")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.WriteString(yamlContent); err != nil {
+		t.Fatalf("Failed to write YAML content: %v", err)
+	}
+	tmpFile.Close()
+
+	os.Setenv("SERVER_HOST", "env-host")
+	os.Setenv("SERVER_TLS", "true")
+	defer os.Unsetenv("SERVER_HOST")
+	defer os.Unsetenv("SERVER_TLS")
+
+	tests := []struct {
+		name       string
+		precedence Precedence
+		expected   string
+	}{
+		{"env over file", EnvOverFile, "env-host"},
+		{"file over env", FileOverEnv, "yaml-host"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := New(WithYAMLFile(tmpFile.Name()), WithPrecedence(tt.precedence))
+			var cfg TestConfig
+
+			if err := config.Load(&cfg); err != nil {
+				t.Fatalf("Failed to load config: %v", err)
+			}
+
+			if cfg.Server.Host != tt.expected {
+				t.Errorf("Expected host '%s', got '%s'", tt.expected, cfg.Server.Host)
+			}
+			// Fields set by only one source are always kept
+			if cfg.Server.Port != 3000 {
+				t.Errorf("Expected port 3000, got %d", cfg.Server.Port)
+			}
+			if !cfg.Server.TLS {
+				t.Error("Expected TLS to be true")
+			}
+		})
+	}
+}
+
+type LayeredConfig struct {
+	Server ServerConfig      `yaml:"server"`
+	Labels map[string]string `yaml:"labels"`
+}
+
+func writeTempYAML(t *testing.T, content string) string {
+	t.Helper()
+
+	tmpFile, err := os.CreateTemp("", "config*.yaml")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	t.Cleanup(func() { os.Remove(tmpFile.Name()) })
+
+	if _, err := tmpFile.WriteString(content); err != nil {
+		t.Fatalf("Failed to write YAML content: %v", err)
+	}
+	tmpFile.Close()
+
+	return tmpFile.Name()
+}
+
+func TestLoadFromMultipleYAMLFiles(t *testing.T) {
+	base := writeTempYAML(t, `
+server:
+  host: base-host
+  port: 3000
+labels:
+  team: platform
+`)
+	prod := writeTempYAML(t, `
+server:
+  port: 443
+  tls: true
+labels:
+  env: prod
+`)
+
+	config := New(WithYAMLFiles(base, "does-not-exist.yaml", prod))
+	var cfg LayeredConfig
+
+	err := config.Load(&cfg)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if cfg.Server.Host != "base-host" {
+		t.Errorf("Expected host 'base-host', got '%s'", cfg.Server.Host)
+	}
+	if cfg.Server.Port != 443 {
+		t.Errorf("Expected overridden port 443, got %d", cfg.Server.Port)
+	}
+	if !cfg.Server.TLS {
+		t.Error("Expected TLS added by second file to be true")
+	}
+	if cfg.Labels["team"] != "platform" || cfg.Labels["env"] != "prod" {
+		t.Errorf("Expected merged labels, got %v", cfg.Labels)
+	}
+}
+
+type NestedMapConfig struct {
+	DBs map[string]struct {
+		Host string `yaml:"host"`
+		Port int    `yaml:"port"`
+	} `yaml:"dbs"`
+	Extra map[string]map[string]int `yaml:"extra"`
+}
+
+func TestLoadYAMLFilesMergesNestedMaps(t *testing.T) {
+	base := writeTempYAML(t, `
+dbs:
+  main:
+    host: db.internal
+    port: 5432
+extra:
+  n:
+    x: 1
+`)
+	override := writeTempYAML(t, `
+dbs:
+  main:
+    port: 6432
+extra:
+  n:
+    y: 2
+`)
+
+	var cfg NestedMapConfig
+	if err := New(WithYAMLFiles(base, override)).Load(&cfg); err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if cfg.DBs["main"].Host != "db.internal" || cfg.DBs["main"].Port != 6432 {
+		t.Errorf("Expected merged map entry, got %+v", cfg.DBs["main"])
+	}
+	if cfg.Extra["n"]["x"] != 1 || cfg.Extra["n"]["y"] != 2 {
+		t.Errorf("Expected merged nested map, got %v", cfg.Extra)
+	}
+}
+
+type VersionConfig struct {
+	Version  string `yaml:"version"`
+	Released string `yaml:"released"`
+}
+
+func TestLoadYAMLFilesKeepScalarsAsWritten(t *testing.T) {
+	base := writeTempYAML(t, `
+version: 1.10
+released: 2023-06-30
+`)
+	override := writeTempYAML(t, `
+released: 2024-01-01
+`)
+
+	var single VersionConfig
+	if err := New(WithYAMLFile(base)).Load(&single); err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if single.Version != "1.10" || single.Released != "2023-06-30" {
+		t.Errorf("Expected values as written, got %+v", single)
+	}
+
+	var merged VersionConfig
+	if err := New(WithYAMLFiles(base, override)).Load(&merged); err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if merged.Version != "1.10" || merged.Released != "2024-01-01" {
+		t.Errorf("Expected merged values as written, got %+v", merged)
+	}
+}
+
+func TestStrictModeReportsFileLine(t *testing.T) {
+	base := writeTempYAML(t, `
+server:
+  host: yaml-host
+`)
+	override := writeTempYAML(t, `
+# comment
+
+server:
+  prot: 3000
+`)
+
+	var cfg TestConfig
+	err := New(WithYAMLFiles(base, override), WithStrict()).Load(&cfg)
+	if err == nil {
+		t.Fatal("Strict mode should fail on unknown keys")
+	}
+	if !strings.Contains(err.Error(), override) || !strings.Contains(err.Error(), "line 5") {
+		t.Errorf("Expected error to point at line 5 of %s, got %v", override, err)
+	}
+}
+
+func TestStrictMode(t *testing.T) {
+	file := writeTempYAML(t, `
+server:
+  host: yaml-host
+  prot: 3000
+`)
+
+	var lenient TestConfig
+	if err := New(WithYAMLFile(file)).Load(&lenient); err != nil {
+		t.Fatalf("Lenient mode should ignore unknown keys: %v", err)
+	}
+	if lenient.Server.Host != "yaml-host" {
+		t.Errorf("Expected host 'yaml-host', got '%s'", lenient.Server.Host)
+	}
+
+	var strict TestConfig
+	err := New(WithYAMLFile(file), WithStrict()).Load(&strict)
+	if err == nil {
+		t.Fatal("Strict mode should fail on unknown keys")
+	}
+	if !strings.Contains(err.Error(), "prot") {
+		t.Errorf("Expected error to name the unknown field, got: %v", err)
+	}
+}
+
+func TestStrictModeEmptyFile(t *testing.T) {
+	file := writeTempYAML(t, "")
+
+	var cfg TestConfig
+	if err := New(WithYAMLFile(file), WithStrict()).Load(&cfg); err != nil {
+		t.Errorf("Empty file should load without error: %v", err)
+	}
+}
+
 func TestPointerFields(t *testing.T) {
 	os.Setenv("REDIS_HOST", "redis-server")
 	os.Setenv("REDIS_PORT", "6379")
@@ -325,6 +577,366 @@ func TestCustomEnvMapping(t *testing.T) {
 	}
 }
 
+type FlatPrefixConfig struct {
+	Server ServerConfig   `prefix:"-"`
+	Redis  *RedisConfig   `prefix:"-"`
+	Cache  DatabaseConfig `prefix:"store"`
+}
+
+func TestPrefixTagSkip(t *testing.T) {
+	os.Setenv("HOST", "flat-host")
+	os.Setenv("PORT", "8181")
+	os.Setenv("DB", "3")
+	defer os.Unsetenv("HOST")
+	defer os.Unsetenv("PORT")
+	defer os.Unsetenv("DB")
+
+	config := New()
+	var cfg FlatPrefixConfig
+
+	err := config.Load(&cfg)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if cfg.Server.Host != "flat-host" {
+		t.Errorf("Expected host 'flat-host', got '%s'", cfg.Server.Host)
+	}
+	if cfg.Server.Port != 8181 {
+		t.Errorf("Expected port 8181, got %d", cfg.Server.Port)
+	}
+	if cfg.Redis == nil {
+		t.Fatal("Expected Redis config to be initialized")
+	}
+	if cfg.Redis.DB != 3 {
+		t.Errorf("Expected Redis DB 3, got %d", cfg.Redis.DB)
+	}
+}
+
+func TestPrefixTagOverride(t *testing.T) {
+	os.Setenv("APP_STORE_URL", "postgres://store/test")
+	os.Setenv("APP_CACHE_URL", "postgres://cache/test")
+	defer os.Unsetenv("APP_STORE_URL")
+	defer os.Unsetenv("APP_CACHE_URL")
+
+	config := New(WithEnvPrefix("APP"))
+	var cfg FlatPrefixConfig
+
+	err := config.Load(&cfg)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if cfg.Cache.URL != "postgres://store/test" {
+		t.Errorf("Expected URL 'postgres://store/test', got '%s'", cfg.Cache.URL)
+	}
+}
+
+type BaseConfig struct {
+	ServiceName string
+	LogLevel    string
+}
+
+type WorkerConfig struct {
+	BaseConfig
+	Queue  QueueConfig
+	Events *QueueConfig `prefix:"events"`
+}
+
+type QueueConfig struct {
+	BaseConfig
+	Name string
+}
+
+type ClusterConfig struct {
+	Name    string         `yaml:"name"`
+	Servers []ServerConfig `yaml:"servers"`
+}
+
+func TestStructSliceEnvOverride(t *testing.T) {
+	file := writeTempYAML(t, `
+name: primary
+servers:
+  - host: alpha
+    port: 8080
+  - host: beta
+    port: 8081
+`)
+
+	os.Setenv("CLUSTER_SERVERS_1_PORT", "9091")
+	defer os.Unsetenv("CLUSTER_SERVERS_1_PORT")
+
+	var cfg ClusterConfig
+	config := New(WithEnvPrefix("CLUSTER"), WithYAMLFile(file))
+	if err := config.Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	expected := []ServerConfig{
+		{Host: "alpha", Port: 8080},
+		{Host: "beta", Port: 9091},
+	}
+	if !reflect.DeepEqual(cfg.Servers, expected) {
+		t.Errorf("Expected servers %+v, got %+v", expected, cfg.Servers)
+	}
+}
+
+func TestStructSliceEnvAppend(t *testing.T) {
+	os.Setenv("CLUSTER_SERVERS_0_HOST", "alpha")
+	os.Setenv("CLUSTER_SERVERS_1_HOST", "beta")
+	os.Setenv("CLUSTER_SERVERS_1_TLS", "true")
+	os.Setenv("CLUSTER_SERVERS_3_HOST", "skipped")
+	defer os.Unsetenv("CLUSTER_SERVERS_0_HOST")
+	defer os.Unsetenv("CLUSTER_SERVERS_1_HOST")
+	defer os.Unsetenv("CLUSTER_SERVERS_1_TLS")
+	defer os.Unsetenv("CLUSTER_SERVERS_3_HOST")
+
+	var cfg ClusterConfig
+	if err := LoadFromEnv(&cfg, WithEnvPrefix("CLUSTER")); err != nil {
+		t.Fatalf("LoadFromEnv failed: %v", err)
+	}
+
+	expected := []ServerConfig{
+		{Host: "alpha"},
+		{Host: "beta", TLS: true},
+	}
+	if !reflect.DeepEqual(cfg.Servers, expected) {
+		t.Errorf("Expected servers %+v, got %+v", expected, cfg.Servers)
+	}
+}
+
+func TestEmbeddedStruct(t *testing.T) {
+	os.Setenv("APP_SERVICE_NAME", "worker")
+	os.Setenv("APP_LOG_LEVEL", "debug")
+	os.Setenv("APP_QUEUE_NAME", "jobs")
+	os.Setenv("APP_QUEUE_SERVICE_NAME", "queue-service")
+	os.Setenv("APP_EVENTS_LOG_LEVEL", "warn")
+	os.Setenv("APP_BASECONFIG_SERVICE_NAME", "wrong")
+	defer os.Unsetenv("APP_SERVICE_NAME")
+	defer os.Unsetenv("APP_LOG_LEVEL")
+	defer os.Unsetenv("APP_QUEUE_NAME")
+	defer os.Unsetenv("APP_QUEUE_SERVICE_NAME")
+	defer os.Unsetenv("APP_EVENTS_LOG_LEVEL")
+	defer os.Unsetenv("APP_BASECONFIG_SERVICE_NAME")
+
+	config := New(WithEnvPrefix("APP"))
+	var cfg WorkerConfig
+
+	err := config.Load(&cfg)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if cfg.ServiceName != "worker" {
+		t.Errorf("Expected service name 'worker', got '%s'", cfg.ServiceName)
+	}
+	if cfg.LogLevel != "debug" {
+		t.Errorf("Expected log level 'debug', got '%s'", cfg.LogLevel)
+	}
+	if cfg.Queue.ServiceName != "queue-service" {
+		t.Errorf("Expected queue service name 'queue-service', got '%s'", cfg.Queue.ServiceName)
+	}
+	if cfg.Queue.Name != "jobs" {
+		t.Errorf("Expected queue name 'jobs', got '%s'", cfg.Queue.Name)
+	}
+	if cfg.Events == nil || cfg.Events.LogLevel != "warn" {
+		t.Errorf("Expected events log level 'warn', got %+v", cfg.Events)
+	}
+}
+
+type Environment string
+
+func (Environment) Values() []string {
+	return []string{"development", "staging", "production"}
+}
+
+type DeployConfig struct {
+	Env    Environment `yaml:"env"`
+	Region string      `yaml:"region"`
+	Canary *struct {
+		Env Environment `yaml:"env"`
+	} `yaml:"canary"`
+}
+
+func TestEnumValidation(t *testing.T) {
+	os.Setenv("ENV", "staging")
+	defer os.Unsetenv("ENV")
+
+	var cfg DeployConfig
+	if err := New().Load(&cfg); err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.Env != "staging" {
+		t.Errorf("Expected env 'staging', got '%s'", cfg.Env)
+	}
+
+	os.Setenv("ENV", "prod")
+
+	var invalid DeployConfig
+	err := New().Load(&invalid)
+	if err == nil {
+		t.Fatal("Expected error for value outside the enum")
+	}
+	if !strings.Contains(err.Error(), `"prod"`) || !strings.Contains(err.Error(), "development, staging, production") {
+		t.Errorf("Expected error to name the value and allowed values, got: %v", err)
+	}
+}
+
+func TestEnumValidationYAML(t *testing.T) {
+	file := writeTempYAML(t, `
+env: production
+canary:
+  env: qa
+`)
+
+	var cfg DeployConfig
+	err := New(WithYAMLFile(file)).Load(&cfg)
+	if err == nil {
+		t.Fatal("Expected error for nested value outside the enum")
+	}
+	if !strings.Contains(err.Error(), "Canary.Env") {
+		t.Errorf("Expected error to name the field path, got: %v", err)
+	}
+}
+
+// Tier implements Enum with a pointer receiver
+type Tier string
+
+func (t *Tier) Values() []string {
+	return []string{"free", "pro"}
+}
+
+type PlanConfig struct {
+	Tier Tier `yaml:"tier"`
+}
+
+func TestEnumValidationPointerReceiver(t *testing.T) {
+	file := writeTempYAML(t, "tier: enterprise\n")
+
+	var cfg PlanConfig
+	err := New(WithYAMLFile(file)).Load(&cfg)
+	if err == nil || !strings.Contains(err.Error(), "free, pro") {
+		t.Errorf("Expected pointer receiver enums to be checked, got: %v", err)
+	}
+}
+
+func TestEnumValidationLoadFromEnv(t *testing.T) {
+	os.Setenv("ENV", "prod")
+	defer os.Unsetenv("ENV")
+
+	var cfg DeployConfig
+	err := LoadFromEnv(&cfg)
+	if err == nil || !strings.Contains(err.Error(), `"prod"`) {
+		t.Errorf("Expected LoadFromEnv to check enums, got: %v", err)
+	}
+
+	os.Setenv("ENV", "staging")
+	if err := LoadFromEnv(&cfg); err != nil || cfg.Env != "staging" {
+		t.Errorf("Expected valid enum value to load, got %q, %v", cfg.Env, err)
+	}
+}
+
+type MigratingConfig struct {
+	DBURL   string `envalias:"OLD_DB_URL, LEGACY_URL"`
+	Timeout string `envalias:"LEGACY_TIMEOUT"`
+}
+
+func TestEnvAlias(t *testing.T) {
+	os.Setenv("LEGACY_URL", "postgres://legacy/test")
+	os.Setenv("APP_TIMEOUT", "5s")
+	os.Setenv("LEGACY_TIMEOUT", "10s")
+	defer os.Unsetenv("LEGACY_URL")
+	defer os.Unsetenv("APP_TIMEOUT")
+	defer os.Unsetenv("LEGACY_TIMEOUT")
+
+	var used []string
+	config := New(WithEnvPrefix("APP"), WithAliasHook(func(envName, alias string) {
+		used = append(used, envName+"<-"+alias)
+	}))
+	var cfg MigratingConfig
+
+	if err := config.Load(&cfg); err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if cfg.DBURL != "postgres://legacy/test" {
+		t.Errorf("Expected URL from alias 'postgres://legacy/test', got '%s'", cfg.DBURL)
+	}
+	if cfg.Timeout != "5s" {
+		t.Errorf("Expected primary env name to win over alias, got '%s'", cfg.Timeout)
+	}
+	if len(used) != 1 || used[0] != "APP_DBURL<-LEGACY_URL" {
+		t.Errorf("Expected alias hook to report APP_DBURL<-LEGACY_URL, got %v", used)
+	}
+
+	os.Setenv("OLD_DB_URL", "postgres://old/test")
+	defer os.Unsetenv("OLD_DB_URL")
+
+	var ordered MigratingConfig
+	if err := config.Load(&ordered); err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if ordered.DBURL != "postgres://old/test" {
+		t.Errorf("Expected first alias to win, got '%s'", ordered.DBURL)
+	}
+}
+
+type NetworkConfig struct {
+	BindIP   net.IP
+	Endpoint url.URL
+	Proxy    *url.URL
+}
+
+func TestCustomDecoders(t *testing.T) {
+	os.Setenv("BIND_IP", "10.0.0.1")
+	os.Setenv("ENDPOINT", "https://example.com/api")
+	os.Setenv("PROXY", "http://proxy:3128")
+	defer os.Unsetenv("BIND_IP")
+	defer os.Unsetenv("ENDPOINT")
+	defer os.Unsetenv("PROXY")
+
+	decodeIP := func(value string) (interface{}, error) {
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP: %s", value)
+		}
+		return ip, nil
+	}
+	decodeURL := func(value string) (interface{}, error) {
+		u, err := url.Parse(value)
+		if err != nil {
+			return nil, err
+		}
+		return *u, nil
+	}
+
+	config := New(
+		WithDecoder(reflect.TypeOf(net.IP{}), decodeIP),
+		WithDecoder(reflect.TypeOf(url.URL{}), decodeURL),
+	)
+	var cfg NetworkConfig
+
+	err := config.Load(&cfg)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if !cfg.BindIP.Equal(net.ParseIP("10.0.0.1")) {
+		t.Errorf("Expected IP 10.0.0.1, got %v", cfg.BindIP)
+	}
+	if cfg.Endpoint.Host != "example.com" || cfg.Endpoint.Path != "/api" {
+		t.Errorf("Expected endpoint https://example.com/api, got %v", cfg.Endpoint.String())
+	}
+	if cfg.Proxy == nil || cfg.Proxy.Host != "proxy:3128" {
+		t.Errorf("Expected proxy http://proxy:3128, got %v", cfg.Proxy)
+	}
+
+	os.Setenv("BIND_IP", "not-an-ip")
+	if err := config.Load(&cfg); err == nil {
+		t.Error("Expected error from failing decoder")
+	}
+}
+
 func TestValidation(t *testing.T) {
 	config := New()
 
@@ -518,6 +1130,212 @@ func TestLoadFromEnvFunction(t *testing.T) {
 	}
 }
 
+func TestBoolAliases(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected bool
+	}{
+		{"yes", true},
+		{"OFF", false},
+		{"On", true},
+		{"no", false},
+		{"1", true},
+		{"0", false},
+		{"TRUE", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			os.Setenv("BOOL_SERVER_TLS", tt.value)
+			defer os.Unsetenv("BOOL_SERVER_TLS")
+
+			cfg := TestConfig{Server: ServerConfig{TLS: !tt.expected}}
+			if err := LoadFromEnv(&cfg, WithEnvPrefix("BOOL")); err != nil {
+				t.Fatalf("LoadFromEnv failed: %v", err)
+			}
+			if cfg.Server.TLS != tt.expected {
+				t.Errorf("Expected TLS %v for %q, got %v", tt.expected, tt.value, cfg.Server.TLS)
+			}
+		})
+	}
+
+	os.Setenv("BOOL_SERVER_TLS", "maybe")
+	defer os.Unsetenv("BOOL_SERVER_TLS")
+
+	var cfg TestConfig
+	err := LoadFromEnv(&cfg, WithEnvPrefix("BOOL"))
+	if err == nil || !strings.Contains(err.Error(), `invalid bool value "maybe"`) {
+		t.Errorf("Expected invalid bool error, got %v", err)
+	}
+}
+
+func TestLoadContext(t *testing.T) {
+	file := writeTempYAML(t, `
+server:
+  host: ctx-host
+  port: 8080
+`)
+
+	config := New(WithYAMLFile(file))
+
+	var cfg TestConfig
+	if err := config.LoadContext(context.Background(), &cfg); err != nil {
+		t.Fatalf("LoadContext failed: %v", err)
+	}
+	if cfg.Server.Host != "ctx-host" {
+		t.Errorf("Expected host 'ctx-host', got '%s'", cfg.Server.Host)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var cancelled TestConfig
+	err := config.LoadContext(ctx, &cancelled)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if cancelled.Server.Host != "" {
+		t.Error("Expected nothing to be loaded with a cancelled context")
+	}
+
+	if _, err := readFileContext(ctx, file); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected file read to stop on cancelled context, got %v", err)
+	}
+}
+
+func TestFlagsOverrideEnvAndYAML(t *testing.T) {
+	file := writeTempYAML(t, `
+server:
+  host: yaml-host
+  port: 3000
+database:
+  url: postgres://yaml-db/test
+`)
+
+	os.Setenv("APP_SERVER_HOST", "env-host")
+	os.Setenv("APP_SERVER_PORT", "8080")
+	defer os.Unsetenv("APP_SERVER_HOST")
+	defer os.Unsetenv("APP_SERVER_PORT")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("server-host", "flag-default", "server host")
+	fs.Int("server.port", 0, "server port")
+	fs.String("database-url", "flag-default-url", "database url")
+	if err := fs.Parse([]string{"-server-host=flag-host"}); err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+
+	config := New(WithEnvPrefix("APP"), WithYAMLFile(file), WithFlags(fs))
+	var cfg TestConfig
+
+	if err := config.Load(&cfg); err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if cfg.Server.Host != "flag-host" {
+		t.Errorf("Expected flag to win with 'flag-host', got '%s'", cfg.Server.Host)
+	}
+	if cfg.Server.Port != 8080 {
+		t.Errorf("Expected unset flag to keep env port 8080, got %d", cfg.Server.Port)
+	}
+	if cfg.Database.URL != "postgres://yaml-db/test" {
+		t.Errorf("Expected unset flag to keep YAML URL, got '%s'", cfg.Database.URL)
+	}
+}
+
+func TestGenericLoad(t *testing.T) {
+	os.Setenv("GEN_SERVER_HOST", "generic-host")
+	os.Setenv("GEN_SERVER_PORT", "9100")
+	os.Setenv("GEN_DATABASE_URL", "postgres://generic/test")
+	defer os.Unsetenv("GEN_SERVER_HOST")
+	defer os.Unsetenv("GEN_SERVER_PORT")
+	defer os.Unsetenv("GEN_DATABASE_URL")
+
+	cfg, err := Load[TestConfig](WithEnvPrefix("GEN"))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.Server.Host != "generic-host" {
+		t.Errorf("Expected host 'generic-host', got '%s'", cfg.Server.Host)
+	}
+	if cfg.Server.Port != 9100 {
+		t.Errorf("Expected port 9100, got %d", cfg.Server.Port)
+	}
+	if cfg.Database.URL != "postgres://generic/test" {
+		t.Errorf("Expected URL 'postgres://generic/test', got '%s'", cfg.Database.URL)
+	}
+}
+
+func TestGenericLoadValidates(t *testing.T) {
+	os.Setenv("GEN_SERVER_HOST", "generic-host")
+	defer os.Unsetenv("GEN_SERVER_HOST")
+
+	if _, err := Load[TestConfig](WithEnvPrefix("GEN")); err == nil {
+		t.Error("Expected validation error for missing required fields")
+	}
+
+	if _, err := Load[string](); err == nil {
+		t.Error("Expected error for non-struct type")
+	}
+}
+
+type OnceConfig struct {
+	Endpoint url.URL
+}
+
+func TestOnce(t *testing.T) {
+	os.Setenv("ONCE_ENDPOINT", "https://first.example.com")
+	defer os.Unsetenv("ONCE_ENDPOINT")
+
+	var decodes atomic.Int32
+	decodeURL := func(value string) (interface{}, error) {
+		decodes.Add(1)
+		u, err := url.Parse(value)
+		if err != nil {
+			return nil, err
+		}
+		return *u, nil
+	}
+
+	get := Once[OnceConfig](WithEnvPrefix("ONCE"), WithDecoder(reflect.TypeOf(url.URL{}), decodeURL))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := get(); err != nil {
+				t.Errorf("Once loader failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	os.Setenv("ONCE_ENDPOINT", "https://second.example.com")
+	cfg, err := get()
+	if err != nil {
+		t.Fatalf("Once loader failed: %v", err)
+	}
+
+	if decodes.Load() != 1 {
+		t.Errorf("Expected config to be loaded once, decoded %d times", decodes.Load())
+	}
+	if cfg.Endpoint.Host != "first.example.com" {
+		t.Errorf("Expected cached endpoint, got %s", cfg.Endpoint.Host)
+	}
+}
+
+func TestOnceCachesError(t *testing.T) {
+	get := Once[TestConfig](WithEnvPrefix("ONCE_MISSING"))
+
+	_, first := get()
+	_, second := get()
+	if first == nil || first != second {
+		t.Errorf("Expected the same cached error, got %v and %v", first, second)
+	}
+}
+
 // Benchmark tests
 func BenchmarkLoadFromEnv(b *testing.B) {
 	os.Setenv("SERVER_HOST", "localhost")
@@ -0,0 +1,123 @@
+package haconfig
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// maskedValue replaces the values of secret fields in a diff
+const maskedValue = "***"
+
+// FieldChange describes a field whose value differs between two configs
+type FieldChange struct {
+	Path string
+	Old  interface{}
+	New  interface{}
+}
+
+// Diff compares two configs of the same struct type and returns the changed fields.
+// Nested and pointer structs are compared recursively, a nil pointer compares as
+// its zero value. Fields tagged secret:"true", and slices or maps of structs
+// holding such fields, are reported with masked values.
+func Diff(oldCfg, newCfg interface{}) ([]FieldChange, error) {
+	oldValue := reflect.ValueOf(oldCfg)
+	newValue := reflect.ValueOf(newCfg)
+
+	if !oldValue.IsValid() || !newValue.IsValid() {
+		return nil, fmt.Errorf("configs cannot be nil")
+	}
+	if oldValue.Type() != newValue.Type() {
+		return nil, fmt.Errorf("configs must have the same type, got %v and %v", oldValue.Type(), newValue.Type())
+	}
+
+	oldValue, newValue = derefStruct(oldValue), derefStruct(newValue)
+	if oldValue.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("config must be a struct or pointer to struct")
+	}
+
+	var changes []FieldChange
+	diffStruct(oldValue, newValue, "", false, &changes)
+	return changes, nil
+}
+
+// diffStruct appends the changed fields of two struct values
+func diffStruct(oldValue, newValue reflect.Value, prefix string, secret bool, changes *[]FieldChange) {
+	t := oldValue.Type()
+	for i := 0; i < t.NumField(); i++ {
+		fieldType := t.Field(i)
+
+		// Skip unexported fields
+		if !fieldType.IsExported() {
+			continue
+		}
+
+		path := fieldType.Name
+		if prefix != "" {
+			path = prefix + "." + fieldType.Name
+		}
+		fieldSecret := secret || fieldType.Tag.Get("secret") == "true"
+
+		oldField := derefStruct(oldValue.Field(i))
+		newField := derefStruct(newValue.Field(i))
+
+		if oldField.Kind() == reflect.Struct && oldField.Type() != reflect.TypeOf(time.Time{}) {
+			diffStruct(oldField, newField, path, fieldSecret, changes)
+			continue
+		}
+
+		oldInterface, newInterface := oldField.Interface(), newField.Interface()
+		if reflect.DeepEqual(oldInterface, newInterface) {
+			continue
+		}
+
+		// Slices and maps holding secret fields are masked as a whole
+		if fieldSecret || containsSecret(oldField.Type()) {
+			oldInterface, newInterface = maskedValue, maskedValue
+		}
+		*changes = append(*changes, FieldChange{Path: path, Old: oldInterface, New: newInterface})
+	}
+}
+
+// containsSecret reports whether values of t hold a field tagged
+// secret:"true", looking through pointers, slices, arrays and maps
+func containsSecret(t reflect.Type) bool {
+	return hasSecretField(t, make(map[reflect.Type]bool))
+}
+
+// hasSecretField walks t for containsSecret, visiting each type once
+func hasSecretField(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return hasSecretField(t.Elem(), seen)
+	case reflect.Map:
+		return hasSecretField(t.Key(), seen) || hasSecretField(t.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if field.Tag.Get("secret") == "true" || hasSecretField(field.Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// derefStruct dereferences pointers to structs, using the zero value for nil pointers
+func derefStruct(v reflect.Value) reflect.Value {
+	if v.Kind() != reflect.Ptr || v.Type().Elem().Kind() != reflect.Struct {
+		return v
+	}
+	if v.IsNil() {
+		return reflect.Zero(v.Type().Elem())
+	}
+	return v.Elem()
+}
@@ -0,0 +1,121 @@
+package haconfig
+
+import (
+	"testing"
+)
+
+type AuditConfig struct {
+	Server   ServerConfig `yaml:"server"`
+	Database *AuditDatabase
+	Tags     []string
+}
+
+type AuditDatabase struct {
+	URL         string
+	Credentials *AuditCredentials
+}
+
+type AuditCredentials struct {
+	Username string
+	Password string `secret:"true"`
+}
+
+func TestDiff(t *testing.T) {
+	oldCfg := AuditConfig{
+		Server: ServerConfig{Host: "localhost", Port: 8080},
+		Database: &AuditDatabase{
+			URL:         "postgres://localhost/app",
+			Credentials: &AuditCredentials{Username: "app", Password: "old-secret"},
+		},
+		Tags: []string{"a"},
+	}
+	newCfg := AuditConfig{
+		Server: ServerConfig{Host: "localhost", Port: 9090},
+		Database: &AuditDatabase{
+			URL:         "postgres://localhost/app",
+			Credentials: &AuditCredentials{Username: "app", Password: "new-secret"},
+		},
+		Tags: []string{"a"},
+	}
+
+	changes, err := Diff(&oldCfg, &newCfg)
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 changes, got %d: %+v", len(changes), changes)
+	}
+
+	if changes[0].Path != "Server.Port" || changes[0].Old != 8080 || changes[0].New != 9090 {
+		t.Errorf("Unexpected port change: %+v", changes[0])
+	}
+	if changes[1].Path != "Database.Credentials.Password" {
+		t.Errorf("Expected password change, got %+v", changes[1])
+	}
+	if changes[1].Old != "***" || changes[1].New != "***" {
+		t.Errorf("Expected secret values to be masked, got %+v", changes[1])
+	}
+}
+
+func TestDiffNilPointer(t *testing.T) {
+	oldCfg := AuditConfig{}
+	newCfg := AuditConfig{Database: &AuditDatabase{URL: "postgres://new/app"}}
+
+	changes, err := Diff(oldCfg, newCfg)
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+
+	if len(changes) != 1 || changes[0].Path != "Database.URL" || changes[0].New != "postgres://new/app" {
+		t.Errorf("Unexpected changes: %+v", changes)
+	}
+}
+
+type AuditReplicas struct {
+	Replicas []AuditCredentials
+	ByName   map[string]*AuditCredentials
+	Tags     []string
+}
+
+func TestDiffMasksSecretsInContainers(t *testing.T) {
+	oldCfg := AuditReplicas{
+		Replicas: []AuditCredentials{{Username: "app", Password: "old-secret"}},
+		ByName:   map[string]*AuditCredentials{"main": {Username: "app", Password: "old-secret"}},
+		Tags:     []string{"a"},
+	}
+	newCfg := AuditReplicas{
+		Replicas: []AuditCredentials{{Username: "app", Password: "new-secret"}},
+		ByName:   map[string]*AuditCredentials{"main": {Username: "app", Password: "new-secret"}},
+		Tags:     []string{"b"},
+	}
+
+	changes, err := Diff(oldCfg, newCfg)
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+
+	if len(changes) != 3 {
+		t.Fatalf("Expected 3 changes, got %d: %+v", len(changes), changes)
+	}
+	for _, change := range changes[:2] {
+		if change.Old != "***" || change.New != "***" {
+			t.Errorf("Expected %s to be masked, got %+v", change.Path, change)
+		}
+	}
+	if changes[2].Path != "Tags" || changes[2].Old == "***" {
+		t.Errorf("Expected plain tags change, got %+v", changes[2])
+	}
+}
+
+func TestDiffErrors(t *testing.T) {
+	if _, err := Diff(AuditConfig{}, ServerConfig{}); err == nil {
+		t.Error("Expected error for different types")
+	}
+	if _, err := Diff("a", "b"); err == nil {
+		t.Error("Expected error for non-struct configs")
+	}
+	if _, err := Diff(nil, nil); err == nil {
+		t.Error("Expected error for nil configs")
+	}
+}
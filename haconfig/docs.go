@@ -0,0 +1,194 @@
+package haconfig
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GenerateExample returns a YAML example of cfg, using the values cfg holds
+// as example values. Fields tagged desc:"..." get a # comment above them,
+// fields tagged secret:"true" are masked like in GenerateMarkdown.
+func (c *Config) GenerateExample(cfg interface{}) (string, error) {
+	v := derefStruct(reflect.ValueOf(cfg))
+	if v.Kind() != reflect.Struct {
+		return "", fmt.Errorf("config must be a struct or pointer to struct")
+	}
+
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	if err := c.exampleFields(node, v, false); err != nil {
+		return "", err
+	}
+
+	out, err := yaml.Marshal(node)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal example: %w", err)
+	}
+	return string(out), nil
+}
+
+// exampleFields appends the fields of the struct v to the YAML mapping node.
+// Inline structs are flattened into node, like yaml.v3 decodes them.
+func (c *Config) exampleFields(node *yaml.Node, v reflect.Value, secret bool) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		fieldType := t.Field(i)
+		if !fieldType.IsExported() {
+			continue
+		}
+
+		name, inline := yamlName(fieldType)
+		if name == "-" {
+			continue
+		}
+
+		fieldSecret := secret || fieldType.Tag.Get("secret") == "true"
+		field := derefStruct(v.Field(i))
+		isStruct := field.Kind() == reflect.Struct && field.Type() != reflect.TypeOf(time.Time{})
+
+		if inline && isStruct {
+			if err := c.exampleFields(node, field, fieldSecret); err != nil {
+				return err
+			}
+			continue
+		}
+
+		key := &yaml.Node{Kind: yaml.ScalarNode, Value: name, HeadComment: fieldType.Tag.Get("desc")}
+		value, err := c.exampleValue(field, fieldSecret)
+		if err != nil {
+			return fmt.Errorf("failed to encode field %s: %w", fieldType.Name, err)
+		}
+
+		node.Content = append(node.Content, key, value)
+	}
+	return nil
+}
+
+// exampleValue returns the YAML node for a field value. Slices and maps
+// holding secret fields are walked element by element, so those fields are
+// masked like direct ones.
+func (c *Config) exampleValue(field reflect.Value, secret bool) (*yaml.Node, error) {
+	value := &yaml.Node{Kind: yaml.MappingNode}
+	switch {
+	case field.Kind() == reflect.Struct && field.Type() != reflect.TypeOf(time.Time{}):
+		return value, c.exampleFields(value, field, secret)
+	case secret && !c.isZeroValue(field):
+		return value, value.Encode(maskedValue)
+	case !containsSecret(field.Type()):
+		return value, value.Encode(field.Interface())
+	}
+
+	switch field.Kind() {
+	case reflect.Slice, reflect.Array:
+		value.Kind = yaml.SequenceNode
+		for i := 0; i < field.Len(); i++ {
+			elem, err := c.exampleValue(derefStruct(field.Index(i)), secret)
+			if err != nil {
+				return nil, err
+			}
+			value.Content = append(value.Content, elem)
+		}
+	case reflect.Map:
+		iter := field.MapRange()
+		for iter.Next() {
+			key := &yaml.Node{}
+			if err := key.Encode(iter.Key().Interface()); err != nil {
+				return nil, err
+			}
+			elem, err := c.exampleValue(derefStruct(iter.Value()), secret)
+			if err != nil {
+				return nil, err
+			}
+			value.Content = append(value.Content, key, elem)
+		}
+	default:
+		return value, value.Encode(field.Interface())
+	}
+	return value, nil
+}
+
+// yamlName returns the YAML key of a field, following the yaml tag like
+// yaml.v3 does, and whether the field is tagged inline
+func yamlName(fieldType reflect.StructField) (string, bool) {
+	name, opts, _ := strings.Cut(fieldType.Tag.Get("yaml"), ",")
+	inline := slices.Contains(strings.Split(opts, ","), "inline")
+	if name == "" {
+		return strings.ToLower(fieldType.Name), inline
+	}
+	return name, inline
+}
+
+// GenerateMarkdown returns a Markdown table documenting the environment
+// variables of cfg: env name, type, required, default and the desc tag.
+// The values cfg holds are used as defaults, secret fields are masked.
+func (c *Config) GenerateMarkdown(cfg interface{}) string {
+	v := derefStruct(reflect.ValueOf(cfg))
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("| Env | Type | Required | Default | Description |\n")
+	b.WriteString("| --- | --- | --- | --- | --- |\n")
+	c.markdownRows(&b, v, "", false)
+	return b.String()
+}
+
+// markdownRows writes one table row per leaf field of the struct v
+func (c *Config) markdownRows(b *strings.Builder, v reflect.Value, prefix string, secret bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		fieldType := t.Field(i)
+		if !fieldType.IsExported() {
+			continue
+		}
+
+		fieldSecret := secret || fieldType.Tag.Get("secret") == "true"
+
+		switch {
+		case c.isNestedStruct(fieldType.Type), c.isNestedStructPtr(fieldType.Type):
+			c.markdownRows(b, derefStruct(v.Field(i)), c.nestedPrefix(prefix, fieldType), fieldSecret)
+			continue
+		case c.isNestedStructSlice(fieldType.Type):
+			// Elements are documented once, with N standing for the index
+			elem := reflect.Zero(fieldType.Type.Elem())
+			c.markdownRows(b, elem, c.nestedPrefix(prefix, fieldType)+"_N", fieldSecret)
+			continue
+		}
+
+		envName := c.getEnvName(fieldType.Name, prefix)
+		if customName, exists := c.envMapping[fieldType.Name]; exists {
+			envName = customName
+		}
+
+		required := "no"
+		if fieldType.Tag.Get("required") == "true" {
+			required = "yes"
+		}
+
+		var def string
+		if field := v.Field(i); !c.isZeroValue(field) {
+			def = fmt.Sprint(field.Interface())
+			if fieldSecret || containsSecret(fieldType.Type) {
+				def = maskedValue
+			}
+		}
+
+		fmt.Fprintf(b, "| %s | %s | %s | %s | %s |\n",
+			envName,
+			markdownCell(fieldType.Type.String()),
+			required,
+			markdownCell(def),
+			markdownCell(fieldType.Tag.Get("desc")))
+	}
+}
+
+// markdownCell escapes a value for use in a Markdown table cell
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,131 @@
+package haconfig
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type DocsCommon struct {
+	LogLevel string `yaml:"log_level" desc:"Minimum log level"`
+}
+
+type DocsConfig struct {
+	DocsCommon `yaml:",inline"`
+	Server     struct {
+		Host    string        `yaml:"host" required:"true" desc:"Address the server listens on"`
+		Timeout time.Duration `yaml:"timeout" desc:"Request timeout"`
+	} `yaml:"server"`
+	APIKey string `yaml:"api_key" secret:"true" desc:"Key for the upstream API"`
+	Debug  bool   `yaml:"debug"`
+}
+
+func newDocsConfig() DocsConfig {
+	var cfg DocsConfig
+	cfg.Server.Host = "0.0.0.0"
+	cfg.Server.Timeout = 30 * time.Second
+	cfg.APIKey = "s3cr3t"
+	cfg.LogLevel = "info"
+	return cfg
+}
+
+func TestGenerateExample(t *testing.T) {
+	example, err := New().GenerateExample(newDocsConfig())
+	if err != nil {
+		t.Fatalf("Failed to generate example: %v", err)
+	}
+
+	expected := []string{
+		"server:\n",
+		"    # Address the server listens on\n    host: 0.0.0.0\n",
+		"    # Request timeout\n    timeout: 30s\n",
+		"# Key for the upstream API\napi_key: '***'\n",
+		"debug: false\n",
+		"# Minimum log level\nlog_level: info\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(example, want) {
+			t.Errorf("Expected example to contain %q, got:\n%s", want, example)
+		}
+	}
+
+	// The example must load back into the same config
+	file := writeTempYAML(t, example)
+	var loaded DocsConfig
+	if err := New(WithYAMLFile(file)).Load(&loaded); err != nil {
+		t.Fatalf("Failed to load generated example: %v", err)
+	}
+	if strings.Contains(example, "s3cr3t") || strings.Contains(example, "docscommon") {
+		t.Errorf("Expected secrets masked and inline structs flattened, got:\n%s", example)
+	}
+
+	expectedCfg := newDocsConfig()
+	expectedCfg.APIKey = "***"
+	if loaded != expectedCfg {
+		t.Errorf("Expected %+v, got %+v", expectedCfg, loaded)
+	}
+}
+
+type DocsUpstream struct {
+	Name     string `yaml:"name"`
+	Password string `yaml:"password" secret:"true"`
+}
+
+type DocsUpstreamsConfig struct {
+	Upstreams []DocsUpstream           `yaml:"upstreams"`
+	ByName    map[string]*DocsUpstream `yaml:"by_name"`
+}
+
+func TestGenerateExampleMasksSecretsInContainers(t *testing.T) {
+	cfg := DocsUpstreamsConfig{
+		Upstreams: []DocsUpstream{{Name: "primary", Password: "s3cr3t"}},
+		ByName:    map[string]*DocsUpstream{"backup": {Name: "backup", Password: "s3cr3t"}},
+	}
+
+	example, err := New().GenerateExample(cfg)
+	if err != nil {
+		t.Fatalf("Failed to generate example: %v", err)
+	}
+	if strings.Contains(example, "s3cr3t") {
+		t.Errorf("Expected nested secrets masked, got:\n%s", example)
+	}
+
+	file := writeTempYAML(t, example)
+	var loaded DocsUpstreamsConfig
+	if err := New(WithYAMLFile(file)).Load(&loaded); err != nil {
+		t.Fatalf("Failed to load generated example: %v", err)
+	}
+	if len(loaded.Upstreams) != 1 || loaded.Upstreams[0] != (DocsUpstream{Name: "primary", Password: "***"}) {
+		t.Errorf("Unexpected upstreams: %+v", loaded.Upstreams)
+	}
+	if backup := loaded.ByName["backup"]; backup == nil || *backup != (DocsUpstream{Name: "backup", Password: "***"}) {
+		t.Errorf("Unexpected upstreams by name: %+v", loaded.ByName)
+	}
+
+	markdown := New().GenerateMarkdown(cfg)
+	if strings.Contains(markdown, "s3cr3t") {
+		t.Errorf("Expected nested secrets masked in markdown, got:\n%s", markdown)
+	}
+}
+
+func TestGenerateMarkdown(t *testing.T) {
+	markdown := New(WithEnvPrefix("APP")).GenerateMarkdown(newDocsConfig())
+
+	expected := []string{
+		"| Env | Type | Required | Default | Description |\n",
+		"| APP_SERVER_HOST | string | yes | 0.0.0.0 | Address the server listens on |\n",
+		"| APP_SERVER_TIMEOUT | time.Duration | no | 30s | Request timeout |\n",
+		"| APP_API_KEY | string | no | *** | Key for the upstream API |\n",
+		"| APP_DEBUG | bool | no |  |  |\n",
+		"| APP_LOG_LEVEL | string | no | info | Minimum log level |\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected markdown to contain %q, got:\n%s", want, markdown)
+		}
+	}
+
+	if New().GenerateMarkdown("not a struct") != "" {
+		t.Error("Expected empty output for a non-struct config")
+	}
+}
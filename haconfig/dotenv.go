@@ -0,0 +1,118 @@
+package haconfig
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// envLookup returns the function used to read environment variables,
+// merging in the dotenv file when one is configured
+func (c *Config) envLookup(ctx context.Context) (func(string) string, error) {
+	if c.dotEnvFile == "" {
+		return os.Getenv, nil
+	}
+
+	values, err := c.loadDotEnv(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(name string) string {
+		if c.dotEnvWins {
+			if value, ok := values[name]; ok {
+				return value
+			}
+			return os.Getenv(name)
+		}
+		if value, ok := os.LookupEnv(name); ok {
+			return value
+		}
+		return values[name]
+	}, nil
+}
+
+// loadDotEnv reads and parses the dotenv file, returning no values if it doesn't exist
+func (c *Config) loadDotEnv(ctx context.Context) (map[string]string, error) {
+	data, err := readFileContext(ctx, c.dotEnvFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to read dotenv file: %w", err)
+	}
+
+	values, err := parseDotEnv(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse dotenv file %s: %w", c.dotEnvFile, err)
+	}
+	return values, nil
+}
+
+// parseDotEnv parses KEY=VALUE lines. Blank lines and lines starting with #
+// are skipped, an optional "export " prefix is allowed, values may be single
+// or double quoted, and unquoted values end at an inline " #" comment.
+func parseDotEnv(data []byte) (map[string]string, error) {
+	values := make(map[string]string)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNo)
+		}
+
+		value, err := parseDotEnvValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		values[key] = value
+	}
+
+	return values, scanner.Err()
+}
+
+// parseDotEnvValue strips quotes or a trailing comment from a raw value
+func parseDotEnvValue(raw string) (string, error) {
+	if raw == "" {
+		return "", nil
+	}
+
+	switch raw[0] {
+	case '"':
+		end := strings.LastIndex(raw, `"`)
+		if end == 0 {
+			return "", fmt.Errorf("unterminated quoted value")
+		}
+		value, err := strconv.Unquote(raw[:end+1])
+		if err != nil {
+			return "", fmt.Errorf("invalid quoted value: %w", err)
+		}
+		return value, nil
+	case '\'':
+		end := strings.LastIndex(raw, "'")
+		if end == 0 {
+			return "", fmt.Errorf("unterminated quoted value")
+		}
+		return raw[1:end], nil
+	}
+
+	if i := strings.Index(raw, " #"); i >= 0 {
+		raw = strings.TrimSpace(raw[:i])
+	}
+	return raw, nil
+}
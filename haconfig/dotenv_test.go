@@ -0,0 +1,93 @@
+package haconfig
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+type DotEnvConfig struct {
+	Host    string `yaml:"host"`
+	Port    int    `yaml:"port"`
+	Name    string `yaml:"name"`
+	Secret  string `yaml:"secret"`
+	Comment string `yaml:"comment"`
+}
+
+func writeTempDotEnv(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write dotenv file: %v", err)
+	}
+	return path
+}
+
+func TestWithDotEnv(t *testing.T) {
+	file := writeTempDotEnv(t, `
+# local development settings
+DOTENV_HOST=dotenv-host
+export DOTENV_PORT=9090
+DOTENV_NAME="my app"
+DOTENV_SECRET='s3cr#t'
+DOTENV_COMMENT=value # trailing comment
+`)
+
+	os.Setenv("DOTENV_HOST", "process-host")
+	defer os.Unsetenv("DOTENV_HOST")
+
+	var cfg DotEnvConfig
+	config := New(WithEnvPrefix("DOTENV"), WithDotEnv(file))
+	if err := config.Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	expected := DotEnvConfig{
+		Host:    "process-host",
+		Port:    9090,
+		Name:    "my app",
+		Secret:  "s3cr#t",
+		Comment: "value",
+	}
+	if !reflect.DeepEqual(cfg, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, cfg)
+	}
+}
+
+func TestWithDotEnvOverride(t *testing.T) {
+	file := writeTempDotEnv(t, "DOTENV_HOST=dotenv-host\n")
+
+	os.Setenv("DOTENV_HOST", "process-host")
+	os.Setenv("DOTENV_PORT", "8080")
+	defer os.Unsetenv("DOTENV_HOST")
+	defer os.Unsetenv("DOTENV_PORT")
+
+	var cfg DotEnvConfig
+	config := New(WithEnvPrefix("DOTENV"), WithDotEnv(file), WithDotEnvOverride())
+	if err := config.Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.Host != "dotenv-host" {
+		t.Errorf("Expected dotenv value to win, got %s", cfg.Host)
+	}
+	if cfg.Port != 8080 {
+		t.Errorf("Expected process env to fill keys missing from the file, got %d", cfg.Port)
+	}
+}
+
+func TestWithDotEnvErrors(t *testing.T) {
+	var cfg DotEnvConfig
+
+	missing := New(WithDotEnv(filepath.Join(t.TempDir(), "missing.env")))
+	if err := missing.Load(&cfg); err != nil {
+		t.Errorf("Expected missing dotenv file to be ignored, got %v", err)
+	}
+
+	invalid := New(WithDotEnv(writeTempDotEnv(t, "NOT A PAIR\n")))
+	if err := invalid.Load(&cfg); err == nil {
+		t.Error("Expected error for malformed dotenv line")
+	}
+}
@@ -0,0 +1,48 @@
+package haconfig
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Holder keeps the current config for concurrent readers. Each Store swaps in
+// a new value atomically, so readers see either the old or the new config,
+// never a mix of both.
+type Holder[T any] struct {
+	current atomic.Pointer[T]
+}
+
+// NewHolder creates a holder containing cfg
+func NewHolder[T any](cfg T) *Holder[T] {
+	h := &Holder[T]{}
+	h.Store(cfg)
+	return h
+}
+
+// Store replaces the current config
+func (h *Holder[T]) Store(cfg T) {
+	h.current.Store(&cfg)
+}
+
+// Load returns the current config, or the zero value if none was stored
+func (h *Holder[T]) Load() T {
+	if cfg := h.current.Load(); cfg != nil {
+		return *cfg
+	}
+	var zero T
+	return zero
+}
+
+// Reload loads and validates a fresh T with c and stores it. The current
+// config is kept if loading or validation fails.
+func (h *Holder[T]) Reload(ctx context.Context, c *Config) error {
+	var cfg T
+	if err := c.LoadContext(ctx, &cfg); err != nil {
+		return err
+	}
+	if err := c.Validate(&cfg); err != nil {
+		return err
+	}
+	h.Store(cfg)
+	return nil
+}
@@ -0,0 +1,80 @@
+package haconfig
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+)
+
+type HolderConfig struct {
+	Version int `yaml:"version"`
+	Mirror  int `yaml:"mirror"`
+}
+
+func TestHolderConcurrentReaders(t *testing.T) {
+	holder := NewHolder(HolderConfig{Version: 0, Mirror: 0})
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				cfg := holder.Load()
+				if cfg.Version != cfg.Mirror {
+					t.Errorf("Read torn config: %+v", cfg)
+					return
+				}
+			}
+		}()
+	}
+
+	for v := 1; v <= 1000; v++ {
+		holder.Store(HolderConfig{Version: v, Mirror: v})
+	}
+	close(done)
+	wg.Wait()
+
+	if holder.Load().Version != 1000 {
+		t.Errorf("Expected last stored config, got %+v", holder.Load())
+	}
+}
+
+func TestHolderZeroValue(t *testing.T) {
+	var holder Holder[HolderConfig]
+	if cfg := holder.Load(); cfg != (HolderConfig{}) {
+		t.Errorf("Expected zero config, got %+v", cfg)
+	}
+}
+
+func TestHolderReload(t *testing.T) {
+	holder := NewHolder(HolderConfig{Version: 1, Mirror: 1})
+
+	os.Setenv("HOLDER_VERSION", "2")
+	os.Setenv("HOLDER_MIRROR", "2")
+	defer os.Unsetenv("HOLDER_VERSION")
+	defer os.Unsetenv("HOLDER_MIRROR")
+
+	if err := holder.Reload(context.Background(), New(WithEnvPrefix("HOLDER"))); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if cfg := holder.Load(); cfg.Version != 2 || cfg.Mirror != 2 {
+		t.Errorf("Expected reloaded config, got %+v", cfg)
+	}
+
+	os.Setenv("HOLDER_VERSION", "not-a-number")
+	if err := holder.Reload(context.Background(), New(WithEnvPrefix("HOLDER"))); err == nil {
+		t.Error("Expected reload error for invalid value")
+	}
+	if cfg := holder.Load(); cfg.Version != 2 {
+		t.Errorf("Expected config to be kept after failed reload, got %+v", cfg)
+	}
+}
@@ -0,0 +1,204 @@
+package haconfig
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// LoadMap loads the merged configuration as a nested map, from the same
+// sources as Load: YAML files, environment variables (including a dotenv file
+// and env mappings) and flags. Environment keys are lower-cased and split on
+// "_" into nested maps. With an env prefix every variable carrying the prefix
+// is included, without one only variables naming a key of the YAML files are.
+func (c *Config) LoadMap() (map[string]any, error) {
+	ctx := context.Background()
+
+	fileValues := make(map[string]any)
+	if err := c.loadYAMLMap(fileValues); err != nil {
+		return nil, err
+	}
+	envValues, err := c.loadEnvMap(ctx, fileValues)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sources are applied in order, so the last one wins
+	sources := []map[string]any{fileValues, envValues}
+	if c.precedence == FileOverEnv {
+		sources = []map[string]any{envValues, fileValues}
+	}
+	if c.flags != nil {
+		sources = append(sources, c.loadFlagMap(fileValues))
+	}
+
+	result := make(map[string]any)
+	for _, values := range sources {
+		mergeMaps(result, values)
+	}
+	return result, nil
+}
+
+// loadYAMLMap merges every YAML file into the map
+func (c *Config) loadYAMLMap(dst map[string]any) error {
+	for _, file := range c.yamlPaths() {
+		values, err := readYAMLMap(context.Background(), file)
+		if err != nil {
+			return fmt.Errorf("failed to load YAML: %w", err)
+		}
+		mergeMaps(dst, values)
+	}
+	return nil
+}
+
+// loadEnvMap returns the environment variables and dotenv values matching the
+// prefix as a nested map, resolving their keys against the YAML values
+func (c *Config) loadEnvMap(ctx context.Context, fileValues map[string]any) (map[string]any, error) {
+	lookup, err := c.envLookup(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load from env: %w", err)
+	}
+
+	names := make(map[string]bool)
+	for _, env := range os.Environ() {
+		name, _, _ := strings.Cut(env, "=")
+		names[name] = true
+	}
+	if c.dotEnvFile != "" {
+		dotEnv, err := c.loadDotEnv(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load from env: %w", err)
+		}
+		for name := range dotEnv {
+			names[name] = true
+		}
+	}
+
+	prefix := ""
+	if c.envPrefix != "" {
+		prefix = strings.ToUpper(c.envPrefix) + "_"
+	}
+
+	values := make(map[string]any)
+	for name := range names {
+		value := lookup(name)
+		if value == "" || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		key := strings.ToLower(strings.TrimPrefix(name, prefix))
+		if key == "" {
+			continue
+		}
+		path, exists := resolveMapPath(fileValues, strings.Split(key, "_"))
+		if prefix == "" && !exists {
+			continue
+		}
+		setPath(values, path, value)
+	}
+
+	// Env mappings name the variable of every YAML key matching the field name
+	for fieldName, envName := range c.envMapping {
+		value := lookup(envName)
+		if value == "" {
+			continue
+		}
+		for _, path := range findMapKeys(fileValues, c.toSnakeCase(fieldName), nil) {
+			setPath(values, path, value)
+		}
+	}
+
+	return values, nil
+}
+
+// loadFlagMap returns the explicitly set flags as a nested map, resolving
+// their names against the YAML values like environment keys
+func (c *Config) loadFlagMap(fileValues map[string]any) map[string]any {
+	values := make(map[string]any)
+	c.flags.Visit(func(f *flag.Flag) {
+		name := strings.NewReplacer("-", "_", ".", "_").Replace(strings.ToLower(f.Name))
+		path, _ := resolveMapPath(fileValues, strings.Split(name, "_"))
+		setPath(values, path, f.Value.String())
+	})
+	return values
+}
+
+// resolveMapPath turns the parts of an environment key into a key path of m.
+// Consecutive parts are joined back with "_" when that matches an existing key,
+// so DATABASE_MAX_CONNS resolves to database.max_conns loaded from YAML. It
+// also reports whether the path names an existing key of m.
+func resolveMapPath(m map[string]any, parts []string) ([]string, bool) {
+	var path []string
+	for {
+		key, rest := matchMapKey(m, parts)
+		path = append(path, key)
+		if len(rest) == 0 {
+			_, exists := m[key]
+			return path, exists
+		}
+
+		next, ok := m[key].(map[string]any)
+		if !ok {
+			next = nil
+		}
+		m, parts = next, rest
+	}
+}
+
+// setPath stores value under the nested key path, creating maps as needed
+func setPath(m map[string]any, path []string, value any) {
+	for _, key := range path[:len(path)-1] {
+		next, ok := m[key].(map[string]any)
+		if !ok {
+			next = make(map[string]any)
+			m[key] = next
+		}
+		m = next
+	}
+	m[path[len(path)-1]] = value
+}
+
+// findMapKeys returns the paths of every key of m, at any depth, named key
+func findMapKeys(m map[string]any, key string, prefix []string) [][]string {
+	var paths [][]string
+	for k, v := range m {
+		path := append(slices.Clone(prefix), k)
+		if k == key {
+			paths = append(paths, path)
+		}
+		if nested, ok := v.(map[string]any); ok {
+			paths = append(paths, findMapKeys(nested, key, path)...)
+		}
+	}
+	return paths
+}
+
+// matchMapKey returns the longest prefix of parts that names an existing key,
+// falling back to the first part, along with the remaining parts
+func matchMapKey(m map[string]any, parts []string) (string, []string) {
+	for i := len(parts); i > 1; i-- {
+		key := strings.Join(parts[:i], "_")
+		if existing, ok := m[key]; ok {
+			if _, isMap := existing.(map[string]any); isMap || i == len(parts) {
+				return key, parts[i:]
+			}
+		}
+	}
+	return parts[0], parts[1:]
+}
+
+// mergeMaps deep merges src into dst, with src values winning
+func mergeMaps(dst, src map[string]any) {
+	for key, value := range src {
+		srcMap, srcIsMap := value.(map[string]any)
+		dstMap, dstIsMap := dst[key].(map[string]any)
+		if srcIsMap && dstIsMap {
+			mergeMaps(dstMap, srcMap)
+			continue
+		}
+		dst[key] = value
+	}
+}
@@ -0,0 +1,145 @@
+package haconfig
+
+import (
+	"flag"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestLoadMap(t *testing.T) {
+	file := writeTempYAML(t, `
+server:
+  host: yaml-host
+  port: 8080
+database:
+  max_conns: 10
+  name: app
+`)
+
+	os.Setenv("PLUGIN_SERVER_HOST", "env-host")
+	os.Setenv("PLUGIN_DATABASE_MAX_CONNS", "50")
+	os.Setenv("PLUGIN_FEATURE_FLAGS_BETA", "true")
+	defer os.Unsetenv("PLUGIN_SERVER_HOST")
+	defer os.Unsetenv("PLUGIN_DATABASE_MAX_CONNS")
+	defer os.Unsetenv("PLUGIN_FEATURE_FLAGS_BETA")
+
+	config := New(WithEnvPrefix("PLUGIN"), WithYAMLFile(file))
+
+	result, err := config.LoadMap()
+	if err != nil {
+		t.Fatalf("LoadMap failed: %v", err)
+	}
+
+	expected := map[string]any{
+		"server": map[string]any{
+			"host": "env-host",
+			"port": 8080,
+		},
+		"database": map[string]any{
+			"max_conns": "50",
+			"name":      "app",
+		},
+		"feature": map[string]any{
+			"flags": map[string]any{
+				"beta": "true",
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestLoadMapFileOverEnv(t *testing.T) {
+	file := writeTempYAML(t, `
+server:
+  host: yaml-host
+`)
+
+	os.Setenv("PLUGIN_SERVER_HOST", "env-host")
+	defer os.Unsetenv("PLUGIN_SERVER_HOST")
+
+	config := New(WithEnvPrefix("PLUGIN"), WithYAMLFile(file), WithPrecedence(FileOverEnv))
+
+	result, err := config.LoadMap()
+	if err != nil {
+		t.Fatalf("LoadMap failed: %v", err)
+	}
+
+	server, ok := result["server"].(map[string]any)
+	if !ok || server["host"] != "yaml-host" {
+		t.Errorf("Expected YAML host to win, got %v", result["server"])
+	}
+}
+
+func TestLoadMapWithoutPrefixOnlyKnownKeys(t *testing.T) {
+	file := writeTempYAML(t, `
+server:
+  host: yaml-host
+`)
+
+	os.Setenv("SERVER_HOST", "env-host")
+	os.Setenv("UNRELATED_SECRET", "hunter2")
+	defer os.Unsetenv("SERVER_HOST")
+	defer os.Unsetenv("UNRELATED_SECRET")
+
+	result, err := New(WithYAMLFile(file)).LoadMap()
+	if err != nil {
+		t.Fatalf("LoadMap failed: %v", err)
+	}
+
+	expected := map[string]any{
+		"server": map[string]any{"host": "env-host"},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected only YAML keys to be read from env, got %v", result)
+	}
+}
+
+func TestLoadMapSources(t *testing.T) {
+	file := writeTempYAML(t, `
+server:
+  host: yaml-host
+  port: 8080
+database:
+  url: yaml-url
+`)
+	dotEnv := writeTempDotEnv(t, "PLUGIN_SERVER_PORT=9090\n")
+
+	os.Setenv("CUSTOM_DB_URL", "mapped-url")
+	defer os.Unsetenv("CUSTOM_DB_URL")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("server-host", "", "")
+	if err := fs.Parse([]string{"-server-host=flag-host"}); err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+
+	config := New(
+		WithEnvPrefix("PLUGIN"),
+		WithYAMLFile(file),
+		WithDotEnv(dotEnv),
+		WithEnvMapping(map[string]string{"URL": "CUSTOM_DB_URL"}),
+		WithFlags(fs),
+	)
+
+	result, err := config.LoadMap()
+	if err != nil {
+		t.Fatalf("LoadMap failed: %v", err)
+	}
+
+	expected := map[string]any{
+		"server": map[string]any{
+			"host": "flag-host",
+			"port": "9090",
+		},
+		"database": map[string]any{
+			"url": "mapped-url",
+		},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
@@ -0,0 +1,129 @@
+package haconfig
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Source identifies where a config value was loaded from
+type Source int
+
+const (
+	// SourceDefault means no source changed the value set before loading
+	SourceDefault Source = iota
+	SourceYAML
+	SourceEnv
+	SourceFlag
+)
+
+// String returns the source name
+func (s Source) String() string {
+	switch s {
+	case SourceDefault:
+		return "default"
+	case SourceYAML:
+		return "yaml"
+	case SourceEnv:
+		return "env"
+	case SourceFlag:
+		return "flag"
+	default:
+		return fmt.Sprintf("Source(%d)", int(s))
+	}
+}
+
+// LoadWithTrace loads configuration like Load and returns the source of every
+// field, keyed by field path as in Diff (e.g. "Server.Host"). A field is
+// attributed to the last source that changed its value, so a source setting
+// the value a field already had does not show up in the trace.
+func (c *Config) LoadWithTrace(cfg interface{}) (map[string]Source, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("config cannot be nil")
+	}
+
+	v := reflect.ValueOf(cfg)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("config must be a pointer to struct")
+	}
+
+	trace := make(map[string]Source)
+	if err := c.load(context.Background(), v, trace); err != nil {
+		return nil, err
+	}
+	for path := range snapshotFields(v.Elem(), "") {
+		if _, ok := trace[path]; !ok {
+			trace[path] = SourceDefault
+		}
+	}
+	return trace, nil
+}
+
+// traceChanges records source for every field that differs between before and
+// after, and returns after
+func traceChanges(before, after map[string]interface{}, source Source, trace map[string]Source) map[string]interface{} {
+	for path, value := range after {
+		if old, ok := before[path]; !ok || !reflect.DeepEqual(old, value) {
+			trace[path] = source
+		}
+	}
+	return after
+}
+
+// snapshotFields returns a copy of every leaf field of the struct v keyed by
+// field path. Nested and pointer structs are walked like in Diff.
+func snapshotFields(v reflect.Value, prefix string) map[string]interface{} {
+	fields := make(map[string]interface{})
+	snapshotStruct(v, prefix, fields)
+	return fields
+}
+
+// snapshotStruct adds the leaf fields of the struct v to fields
+func snapshotStruct(v reflect.Value, prefix string, fields map[string]interface{}) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		fieldType := t.Field(i)
+		if !fieldType.IsExported() {
+			continue
+		}
+
+		path := fieldType.Name
+		if prefix != "" {
+			path = prefix + "." + fieldType.Name
+		}
+
+		field := derefStruct(v.Field(i))
+		if field.Kind() == reflect.Struct && field.Type() != reflect.TypeOf(time.Time{}) {
+			snapshotStruct(field, path, fields)
+			continue
+		}
+		fields[path] = copyValue(field)
+	}
+}
+
+// copyValue returns v as an interface, copying maps and slices so later
+// in-place updates don't change the snapshot
+func copyValue(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Map:
+		if v.IsNil() {
+			return v.Interface()
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), iter.Value())
+		}
+		return c.Interface()
+	case reflect.Slice:
+		if v.IsNil() {
+			return v.Interface()
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(c, v)
+		return c.Interface()
+	default:
+		return v.Interface()
+	}
+}
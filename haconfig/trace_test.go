@@ -0,0 +1,64 @@
+package haconfig
+
+import (
+	"flag"
+	"os"
+	"reflect"
+	"testing"
+)
+
+type TraceConfig struct {
+	Server  TraceServerConfig `yaml:"server"`
+	Name    string            `yaml:"name"`
+	Retries int               `yaml:"retries"`
+}
+
+type TraceServerConfig struct {
+	Host string `yaml:"host"`
+	Port int    `yaml:"port"`
+}
+
+func TestLoadWithTrace(t *testing.T) {
+	file := writeTempYAML(t, `
+server:
+  host: yaml-host
+  port: 8080
+`)
+
+	os.Setenv("TRACE_SERVER_PORT", "9090")
+	os.Setenv("TRACE_NAME", "env-name")
+	defer os.Unsetenv("TRACE_SERVER_PORT")
+	defer os.Unsetenv("TRACE_NAME")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("name", "", "")
+	if err := fs.Parse([]string{"-name", "flag-name"}); err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+
+	cfg := TraceConfig{Retries: 3}
+	config := New(WithEnvPrefix("TRACE"), WithYAMLFile(file), WithFlags(fs))
+	trace, err := config.LoadWithTrace(&cfg)
+	if err != nil {
+		t.Fatalf("LoadWithTrace failed: %v", err)
+	}
+
+	expected := map[string]Source{
+		"Server.Host": SourceYAML,
+		"Server.Port": SourceEnv,
+		"Name":        SourceFlag,
+		"Retries":     SourceDefault,
+	}
+	if !reflect.DeepEqual(trace, expected) {
+		t.Errorf("Expected trace %v, got %v", expected, trace)
+	}
+	if cfg.Server.Host != "yaml-host" || cfg.Server.Port != 9090 || cfg.Name != "flag-name" || cfg.Retries != 3 {
+		t.Errorf("Unexpected config %+v", cfg)
+	}
+}
+
+func TestSourceString(t *testing.T) {
+	if SourceYAML.String() != "yaml" || SourceEnv.String() != "env" {
+		t.Errorf("Unexpected source names %s, %s", SourceYAML, SourceEnv)
+	}
+}
@@ -0,0 +1,73 @@
+package haconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// checkRanges enforces the validate tag bounds of every time.Duration field
+// of v, including fields of nested structs
+func (c *Config) checkRanges(v reflect.Value, t reflect.Type, prefix string) error {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		fieldType := t.Field(i)
+
+		if !fieldType.IsExported() {
+			continue
+		}
+
+		fieldPath := c.buildFieldPath(prefix, fieldType.Name)
+
+		if tag := fieldType.Tag.Get("validate"); tag != "" {
+			if err := c.checkRange(field, tag, fieldPath); err != nil {
+				return err
+			}
+		}
+
+		if field.Kind() == reflect.Ptr && !field.IsNil() && field.Elem().Kind() == reflect.Struct {
+			field = field.Elem()
+		}
+		if field.Kind() == reflect.Struct {
+			if err := c.checkRanges(field, field.Type(), fieldPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkRange enforces the min and max bounds of a `validate:"min=1s,max=5m"`
+// tag on a time.Duration field. Other fields and rules are left to other
+// validators sharing the tag.
+func (c *Config) checkRange(field reflect.Value, tag, fieldPath string) error {
+	if field.Type() != durationType {
+		return nil
+	}
+
+	value := time.Duration(field.Int())
+	for _, rule := range strings.Split(tag, ",") {
+		name, raw, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		if name != "min" && name != "max" {
+			continue
+		}
+
+		bound, err := time.ParseDuration(strings.TrimSpace(raw))
+		if err != nil {
+			return fmt.Errorf("validate tag on %s: invalid %s bound: %w", fieldPath, name, err)
+		}
+
+		if name == "min" && value < bound {
+			return fmt.Errorf("%s must be at least %s, got %s", fieldPath, bound, value)
+		}
+		if name == "max" && value > bound {
+			return fmt.Errorf("%s must be at most %s, got %s", fieldPath, bound, value)
+		}
+	}
+
+	return nil
+}
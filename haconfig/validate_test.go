@@ -0,0 +1,85 @@
+package haconfig
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type TimeoutConfig struct {
+	Server struct {
+		Timeout time.Duration `yaml:"timeout" validate:"min=1s,max=5m"`
+	} `yaml:"server"`
+}
+
+func TestValidateDurationRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		wantErr string
+	}{
+		{"below min", 500 * time.Millisecond, "Server.Timeout must be at least 1s"},
+		{"above max", 10 * time.Minute, "Server.Timeout must be at most 5m0s"},
+		{"in range", 30 * time.Second, ""},
+		{"at bounds", 5 * time.Minute, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg TimeoutConfig
+			cfg.Server.Timeout = tt.timeout
+
+			err := New().Validate(&cfg)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected %s to be valid, got %v", tt.timeout, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateDurationRangeOnLoad(t *testing.T) {
+	file := writeTempYAML(t, "server:\n  timeout: 10m\n")
+
+	var cfg TimeoutConfig
+	err := New(WithYAMLFile(file)).Load(&cfg)
+	if err == nil || !strings.Contains(err.Error(), "Server.Timeout must be at most 5m0s") {
+		t.Errorf("Expected out of range timeout to be rejected at load, got %v", err)
+	}
+
+	if _, err := Load[TimeoutConfig](WithYAMLFile(file)); err == nil {
+		t.Error("Expected generic Load to reject the timeout too")
+	}
+}
+
+func TestValidateRangeTagSharedWithOtherValidators(t *testing.T) {
+	var cfg struct {
+		Name    string        `validate:"required"`
+		Retries int           `validate:"min=1,max=5"`
+		Timeout time.Duration `validate:"required,min=1s"`
+	}
+	cfg.Timeout = 2 * time.Second
+
+	if err := New().Validate(&cfg); err != nil {
+		t.Errorf("Expected unknown rules and non-duration fields to be skipped, got %v", err)
+	}
+
+	cfg.Timeout = time.Millisecond
+	if err := New().Validate(&cfg); err == nil || !strings.Contains(err.Error(), "Timeout must be at least 1s") {
+		t.Errorf("Expected min bound to still apply, got %v", err)
+	}
+}
+
+func TestValidateInvalidRangeTag(t *testing.T) {
+	var badBound struct {
+		Timeout time.Duration `validate:"min=soon"`
+	}
+	if err := New().Validate(&badBound); err == nil || !strings.Contains(err.Error(), "invalid min bound") {
+		t.Errorf("Expected invalid bound error, got %v", err)
+	}
+}
@@ -2,6 +2,8 @@ package o4g_logger
 
 import (
 	"sync"
+
+	"github.com/sirupsen/logrus"
 )
 
 var (
@@ -64,19 +66,29 @@ func Panic(msg ...string) {
 	GetDefaultLogger().Panic(msg)
 }
 
+// OnFatal registers a handler on the global logger that runs before exit on Fatal
+func OnFatal(handler func()) {
+	GetDefaultLogger().OnFatal(handler)
+}
+
 // WithFields creates a new logger entry with the given fields
 func WithFields(fields map[string]interface{}) *Logger {
 	return &Logger{
 		Logger: GetDefaultLogger().WithFields(fields).Logger,
-		config: GetDefaultLogger().config,
+		config: GetDefaultLogger().getConfig(),
 	}
 }
 
+// Entry creates a chainable logrus entry from the default logger with the given fields
+func Entry(fields map[string]interface{}) *logrus.Entry {
+	return GetDefaultLogger().WithFields(fields)
+}
+
 // WithField creates a new logger entry with a single field
 func WithField(key string, value interface{}) *Logger {
 	return &Logger{
 		Logger: GetDefaultLogger().WithField(key, value).Logger,
-		config: GetDefaultLogger().config,
+		config: GetDefaultLogger().getConfig(),
 	}
 }
 
@@ -84,7 +96,7 @@ func WithField(key string, value interface{}) *Logger {
 func WithError(err error) *Logger {
 	return &Logger{
 		Logger: GetDefaultLogger().WithError(err).Logger,
-		config: GetDefaultLogger().config,
+		config: GetDefaultLogger().getConfig(),
 	}
 }
 
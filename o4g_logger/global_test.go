@@ -165,6 +165,33 @@ func TestGlobalWithFields(t *testing.T) {
 	}
 }
 
+func TestGlobalEntry(t *testing.T) {
+	config := DefaultConfig()
+	err := Init(config)
+	if err != nil {
+		t.Fatalf("Failed to initialize: %v", err)
+	}
+
+	base := Entry(map[string]interface{}{"first": 1})
+	if base == nil {
+		t.Fatal("Entry should not return nil")
+	}
+
+	chained := base.WithField("second", 2).WithFields(map[string]interface{}{"third": 3})
+
+	for _, key := range []string{"first", "second", "third"} {
+		if _, ok := chained.Data[key]; !ok {
+			t.Errorf("Expected field %s in chained entry", key)
+		}
+	}
+	if len(base.Data) != 1 {
+		t.Errorf("Chaining should not modify the base entry, got %d fields", len(base.Data))
+	}
+	if chained.Logger != GetDefaultLogger().Logger {
+		t.Error("Entry should use the default logger")
+	}
+}
+
 func TestGlobalLogHTTPRequest(t *testing.T) {
 	config := DefaultConfig()
 	err := Init(config)
@@ -1,10 +1,10 @@
 module github.com/l00pss/helpme/o4g_logger
 
-go 1.24.0
-
-require github.com/sirupsen/logrus v1.9.3
+go 1.25
 
 require (
-	github.com/stretchr/testify v1.11.1 // indirect
-	golang.org/x/sys v0.37.0 // indirect
+	github.com/l00pss/helpme/goerr v0.1.0
+	github.com/sirupsen/logrus v1.9.3
 )
+
+require golang.org/x/sys v0.37.0 // indirect
@@ -1,11 +1,18 @@
 package o4g_logger
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"reflect"
+	"regexp"
 	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -24,6 +31,34 @@ const (
 	PanicLevel LogLevel = "panic"
 )
 
+// levelOrder ranks log levels from least to most severe
+var levelOrder = map[LogLevel]int{
+	TraceLevel: 0,
+	DebugLevel: 1,
+	InfoLevel:  2,
+	WarnLevel:  3,
+	ErrorLevel: 4,
+	FatalLevel: 5,
+	PanicLevel: 6,
+}
+
+// ParseLevel parses a level name case-insensitively, accepting "warning" as an alias for "warn"
+func ParseLevel(s string) (LogLevel, error) {
+	level := LogLevel(strings.ToLower(strings.TrimSpace(s)))
+	if level == "warning" {
+		level = WarnLevel
+	}
+	if _, ok := levelOrder[level]; !ok {
+		return "", fmt.Errorf("invalid log level: %q", s)
+	}
+	return level, nil
+}
+
+// Less reports whether l is less severe than other
+func (l LogLevel) Less(other LogLevel) bool {
+	return levelOrder[l] < levelOrder[other]
+}
+
 // OutputFormat represents different output formats
 type OutputFormat string
 
@@ -34,20 +69,36 @@ const (
 
 // Config holds the logger configuration
 type Config struct {
-	Level           LogLevel     `yaml:"level" json:"level"`
-	Format          OutputFormat `yaml:"format" json:"format"`
-	Output          string       `yaml:"output" json:"output"` // "stdout", "stderr", or file path
-	EnableCaller    bool         `yaml:"enable_caller" json:"enable_caller"`
-	EnableColors    bool         `yaml:"enable_colors" json:"enable_colors"`
-	ServiceName     string       `yaml:"service_name" json:"service_name"`
-	Environment     string       `yaml:"environment" json:"environment"`
-	TimestampFormat string       `yaml:"timestamp_format" json:"timestamp_format"`
+	Level           LogLevel               `yaml:"level" json:"level"`
+	Format          OutputFormat           `yaml:"format" json:"format"`
+	Output          string                 `yaml:"output" json:"output"`                 // "stdout", "stderr", "discard", "syslog", or file path
+	SyslogNetwork   string                 `yaml:"syslog_network" json:"syslog_network"` // empty connects to the local syslog daemon
+	SyslogAddr      string                 `yaml:"syslog_addr" json:"syslog_addr"`
+	EnableCaller    bool                   `yaml:"enable_caller" json:"enable_caller"`
+	TrimCallerPath  bool                   `yaml:"trim_caller_path" json:"trim_caller_path"`   // JSON only, reports caller files relative to their module
+	StructCaller    bool                   `yaml:"struct_caller" json:"struct_caller"`         // JSON only, reports the caller as a nested object
+	StackTraceLevel LogLevel               `yaml:"stack_trace_level" json:"stack_trace_level"` // empty disables stack traces
+	CallerSkip      int                    `yaml:"caller_skip" json:"caller_skip"`             // extra frames to skip when logging through wrappers
+	EnableColors    bool                   `yaml:"enable_colors" json:"enable_colors"`
+	ServiceName     string                 `yaml:"service_name" json:"service_name"`
+	Environment     string                 `yaml:"environment" json:"environment"`
+	TimestampFormat string                 `yaml:"timestamp_format" json:"timestamp_format"`
+	TimeZone        string                 `yaml:"time_zone" json:"time_zone"`                 // e.g. "UTC" or "America/New_York", empty keeps local time
+	DefaultFields   map[string]interface{} `yaml:"default_fields" json:"default_fields"`       // added to every entry unless set at the call site
+	NestFieldsUnder string                 `yaml:"nest_fields_under" json:"nest_fields_under"` // JSON only, empty keeps fields flat
+	RedactFields    []string               `yaml:"redact_fields" json:"redact_fields"`
+	RedactPatterns  []*regexp.Regexp       `yaml:"-" json:"-"` // matched against field keys
 }
 
+// ErrSyslogUnsupported is returned by NewLogger for syslog output on platforms without syslog
+var ErrSyslogUnsupported = errors.New("syslog output is not supported on this platform")
+
 // Logger wraps logrus with additional functionality
 type Logger struct {
 	*logrus.Logger
-	config Config
+	mu         sync.RWMutex // guards config
+	config     Config
+	callerHook *callerSkipHook
 }
 
 // DefaultConfig returns a default logger configuration
@@ -64,6 +115,22 @@ func DefaultConfig() Config {
 	}
 }
 
+// DiscardConfig returns a configuration that writes nothing
+func DiscardConfig() Config {
+	config := DefaultConfig()
+	config.Level = PanicLevel
+	config.Output = "discard"
+	config.EnableCaller = false
+	config.EnableColors = false
+	return config
+}
+
+// NewNoOpLogger creates a logger that discards all output, useful as a safe default in libraries and tests
+func NewNoOpLogger() *Logger {
+	logger, _ := NewLogger(DiscardConfig())
+	return logger
+}
+
 // NewLogger creates a new logger instance with the given configuration
 func NewLogger(config Config) (*Logger, error) {
 	log := logrus.New()
@@ -75,12 +142,40 @@ func NewLogger(config Config) (*Logger, error) {
 	}
 	log.SetLevel(level)
 
+	// Validate the remaining options before the output is opened, so an
+	// invalid config does not leak a file or syslog connection
+	var location *time.Location
+	if config.TimeZone != "" {
+		location, err = time.LoadLocation(config.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time zone: %v", err)
+		}
+	}
+	var stackLevel logrus.Level
+	if config.StackTraceLevel != "" {
+		stackLevel, err = logrus.ParseLevel(string(config.StackTraceLevel))
+		if err != nil {
+			return nil, fmt.Errorf("invalid stack trace level: %v", err)
+		}
+	}
+
 	// Set output
+	var syslogHook logrus.Hook
 	switch config.Output {
 	case "stdout":
 		log.SetOutput(os.Stdout)
 	case "stderr":
 		log.SetOutput(os.Stderr)
+	case "discard":
+		log.SetOutput(io.Discard)
+	case "syslog":
+		// Entries are written by the syslog hook, added after the other hooks
+		hook, err := newSyslogHook(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to syslog: %w", err)
+		}
+		syslogHook = hook
+		log.SetOutput(io.Discard)
 	default:
 		// Assume it's a file path
 		file, err := os.OpenFile(config.Output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
@@ -91,6 +186,53 @@ func NewLogger(config Config) (*Logger, error) {
 	}
 
 	// Set formatter
+	log.SetFormatter(newFormatter(config))
+
+	// Enable caller info if requested
+	log.SetReportCaller(config.EnableCaller)
+
+	// Skip wrapper frames in the reported caller
+	var callerHook *callerSkipHook
+	if config.CallerSkip > 0 {
+		callerHook = newCallerSkipHook(config.CallerSkip)
+		log.AddHook(callerHook)
+	}
+
+	// Convert timestamps before any formatter sees them
+	if location != nil {
+		log.AddHook(NewTimeZoneHook(location))
+	}
+
+	// Attach stack traces to severe entries
+	if config.StackTraceLevel != "" {
+		log.AddHook(NewStackTraceHook(stackLevel))
+	}
+
+	// Add default fields before redaction so they are redacted too
+	if len(config.DefaultFields) > 0 {
+		log.AddHook(NewDefaultFieldsHook(config.DefaultFields))
+	}
+
+	// Redact sensitive fields before any output is written
+	if len(config.RedactFields) > 0 || len(config.RedactPatterns) > 0 {
+		log.AddHook(NewRedactionHook(config.RedactFields, config.RedactPatterns))
+	}
+
+	if syslogHook != nil {
+		log.AddHook(syslogHook)
+	}
+
+	logger := &Logger{
+		Logger:     log,
+		config:     config,
+		callerHook: callerHook,
+	}
+
+	return logger, nil
+}
+
+// newFormatter builds the formatter described by config
+func newFormatter(config Config) logrus.Formatter {
 	switch config.Format {
 	case JSONFormat:
 		formatter := &logrus.JSONFormatter{
@@ -102,29 +244,216 @@ func NewLogger(config Config) (*Logger, error) {
 				logrus.FieldKeyFunc:  "function",
 				logrus.FieldKeyFile:  "file",
 			},
+			DataKey: config.NestFieldsUnder,
+		}
+		if config.TrimCallerPath {
+			formatter.CallerPrettyfier = func(frame *runtime.Frame) (string, string) {
+				return frame.Function, fmt.Sprintf("%s:%d", trimCallerPath(frame.File), frame.Line)
+			}
 		}
-		log.SetFormatter(formatter)
+		if config.StructCaller {
+			return newStructCallerFormatter(formatter, config.TrimCallerPath)
+		}
+		return formatter
 	default:
 		// Use our custom colored formatter for text output
-		formatter := &ColoredFormatter{
+		return &ColoredFormatter{
 			TimestampFormat: config.TimestampFormat,
 			EnableColors:    config.EnableColors,
 			ServiceName:     config.ServiceName,
 			Environment:     config.Environment,
 			EnableCaller:    config.EnableCaller,
 		}
-		log.SetFormatter(formatter)
 	}
+}
 
-	// Enable caller info if requested
-	log.SetReportCaller(config.EnableCaller)
+// ReplaceFormatter rebuilds the formatter from the formatting fields of config:
+// Format, TimestampFormat, EnableColors, EnableCaller, StructCaller,
+// TrimCallerPath, NestFieldsUnder, ServiceName and Environment. Only non-zero
+// fields replace the current ones, so a partial config such as
+// Config{Format: JSONFormat} keeps everything else; boolean options can only
+// be switched on. Level, hooks, output and rate limiting are preserved.
+func (l *Logger) ReplaceFormatter(config Config) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	updated := l.config
+	if config.Format != "" {
+		updated.Format = config.Format
+	}
+	if config.TimestampFormat != "" {
+		updated.TimestampFormat = config.TimestampFormat
+	}
+	if config.NestFieldsUnder != "" {
+		updated.NestFieldsUnder = config.NestFieldsUnder
+	}
+	if config.ServiceName != "" {
+		updated.ServiceName = config.ServiceName
+	}
+	if config.Environment != "" {
+		updated.Environment = config.Environment
+	}
+	updated.EnableColors = updated.EnableColors || config.EnableColors
+	updated.EnableCaller = updated.EnableCaller || config.EnableCaller
+	updated.StructCaller = updated.StructCaller || config.StructCaller
+	updated.TrimCallerPath = updated.TrimCallerPath || config.TrimCallerPath
+
+	formatter := newFormatter(updated)
+	if limited, ok := l.Logger.Formatter.(*rateLimitFormatter); ok {
+		formatter = limited.hook.formatter(formatter)
+	}
 
-	logger := &Logger{
-		Logger: log,
-		config: config,
+	l.config = updated
+	l.Logger.SetReportCaller(updated.EnableCaller)
+	l.Logger.SetFormatter(formatter)
+}
+
+// CallerField is the key of the nested caller object written when StructCaller is set
+const CallerField = "caller"
+
+// structCallerFormatter replaces the flat function and file keys of a JSON
+// formatter with a nested caller object
+type structCallerFormatter struct {
+	json     *logrus.JSONFormatter
+	trimPath bool
+}
+
+func newStructCallerFormatter(json *logrus.JSONFormatter, trimPath bool) *structCallerFormatter {
+	json.CallerPrettyfier = func(*runtime.Frame) (string, string) {
+		return "", ""
 	}
+	return &structCallerFormatter{json: json, trimPath: trimPath}
+}
 
-	return logger, nil
+// Format adds the caller object to a copy of the entry's data and delegates to the JSON formatter
+func (f *structCallerFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	if !entry.HasCaller() {
+		return f.json.Format(entry)
+	}
+
+	data := make(logrus.Fields, len(entry.Data)+1)
+	for k, v := range entry.Data {
+		data[k] = v
+	}
+	file := entry.Caller.File
+	if f.trimPath {
+		file = trimCallerPath(file)
+	}
+	data[CallerField] = map[string]interface{}{
+		"file":     file,
+		"line":     entry.Caller.Line,
+		"function": entry.Caller.Function,
+	}
+
+	withCaller := *entry
+	withCaller.Data = data
+	return f.json.Format(&withCaller)
+}
+
+// moduleRoots caches the module root found for each source directory
+var moduleRoots sync.Map
+
+// moduleVersionDir matches the "@v1.2.3/" segment of module cache paths,
+// including pre-release and pseudo-versions
+var moduleVersionDir = regexp.MustCompile(`@v\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?(\+incompatible)?/`)
+
+// trimCallerPath returns file relative to its module: the part after the last
+// "@version/" for files in the module cache, otherwise the part below the
+// nearest directory containing a go.mod. Other paths are returned unchanged.
+func trimCallerPath(file string) string {
+	if matches := moduleVersionDir.FindAllStringIndex(file, -1); len(matches) > 0 {
+		return file[matches[len(matches)-1][1]:]
+	}
+
+	dir := filepath.Dir(file)
+	root, ok := moduleRoots.Load(dir)
+	if !ok {
+		root = findModuleRoot(dir)
+		moduleRoots.Store(dir, root)
+	}
+	if root == "" {
+		return file
+	}
+	if rel, err := filepath.Rel(root.(string), file); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return file
+}
+
+// findModuleRoot returns the nearest directory at or above dir containing a go.mod, or ""
+func findModuleRoot(dir string) string {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// Clone returns a logger with a copy of l's config that writes to the same
+// output with the same hooks, level and formatter. Unlike derived loggers,
+// later level, hook or formatter changes on either one don't affect the other.
+func (l *Logger) Clone() *Logger {
+	log := logrus.New()
+	log.SetOutput(l.Logger.Out)
+	log.SetLevel(l.Logger.GetLevel())
+	log.SetReportCaller(l.Logger.ReportCaller)
+	log.SetFormatter(l.Logger.Formatter)
+	log.ExitFunc = l.Logger.ExitFunc
+
+	// The clone gets its own caller hook, so SetCallerSkip only affects one of them
+	var callerHook *callerSkipHook
+	swap := make(map[logrus.Hook]logrus.Hook)
+	if l.callerHook != nil {
+		callerHook = newCallerSkipHook(int(l.callerHook.skip.Load()))
+		swap[l.callerHook] = callerHook
+	}
+	log.ReplaceHooks(cloneHooks(l.Logger.Hooks, swap))
+
+	return &Logger{
+		Logger:     log,
+		config:     l.getConfig(),
+		callerHook: callerHook,
+	}
+}
+
+// cloneHooks copies hooks, replacing the hooks found in swap. Hooks wrapped by
+// EnableRateLimit are copied as well, and swap is extended with the copies so
+// each wrapper is copied once.
+func cloneHooks(hooks logrus.LevelHooks, swap map[logrus.Hook]logrus.Hook) logrus.LevelHooks {
+	cloned := make(logrus.LevelHooks, len(hooks))
+	for level, levelHooks := range hooks {
+		cloned[level] = slices.Clone(levelHooks)
+		for i, hook := range cloned[level] {
+			if replacement, ok := swap[hook]; ok {
+				cloned[level][i] = replacement
+				continue
+			}
+			if limited, ok := hook.(*rateLimitedHooks); ok {
+				swap[hook] = limited.clone(swap)
+				cloned[level][i] = swap[hook]
+			}
+		}
+	}
+	return cloned
+}
+
+// getConfig returns a copy of the logger's config
+func (l *Logger) getConfig() Config {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.config
+}
+
+// WithServiceName returns a clone of l reporting the given service name
+func (l *Logger) WithServiceName(name string) *Logger {
+	clone := l.Clone()
+	clone.ReplaceFormatter(Config{ServiceName: name})
+	return clone
 }
 
 // WithFields creates a new logger entry with the given fields
@@ -142,15 +471,87 @@ func (l *Logger) WithError(err error) *logrus.Entry {
 	return l.Logger.WithError(err)
 }
 
+// With creates a new logger entry from alternating key/value pairs.
+// Non-string keys are formatted with fmt.Sprint, and a trailing key without
+// a value is dropped with a warning.
+func (l *Logger) With(kv ...interface{}) *logrus.Entry {
+	fields := make(logrus.Fields, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		key, ok := kv[i].(string)
+		if !ok {
+			key = fmt.Sprint(kv[i])
+		}
+		fields[key] = kv[i+1]
+	}
+
+	if len(kv)%2 != 0 {
+		l.Logger.WithField("ignored_key", kv[len(kv)-1]).Warn("With called with an odd number of arguments")
+	}
+
+	return l.Logger.WithFields(fields)
+}
+
+// WithDuration creates a new logger entry with <key>_ms and <key>_human fields for d
+func (l *Logger) WithDuration(key string, d time.Duration) *logrus.Entry {
+	return l.Logger.WithFields(logrus.Fields{
+		key + "_ms":    d.Milliseconds(),
+		key + "_human": d.String(),
+	})
+}
+
+// DebugCollection logs up to maxItems elements of the slice, array or map
+// items under key at debug level, with a total count and truncated:true
+// when elements were left out. Map elements are taken in key order.
+func (l *Logger) DebugCollection(key string, items any, maxItems int) {
+	if !l.Logger.IsLevelEnabled(logrus.DebugLevel) {
+		return
+	}
+	maxItems = max(maxItems, 0)
+
+	fields := logrus.Fields{}
+	v := reflect.ValueOf(items)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		n := min(v.Len(), maxItems)
+		shown := make([]interface{}, n)
+		for i := range n {
+			shown[i] = v.Index(i).Interface()
+		}
+		fields[key] = shown
+		fields["total"] = v.Len()
+	case reflect.Map:
+		keys := v.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+		})
+		shown := make(map[string]interface{}, min(len(keys), maxItems))
+		for _, k := range keys[:min(len(keys), maxItems)] {
+			shown[fmt.Sprint(k.Interface())] = v.MapIndex(k).Interface()
+		}
+		fields[key] = shown
+		fields["total"] = v.Len()
+	default:
+		fields[key] = items
+		l.Logger.WithFields(fields).Debug(key)
+		return
+	}
+
+	if v.Len() > maxItems {
+		fields["truncated"] = true
+	}
+	l.Logger.WithFields(fields).Debug(key)
+}
+
 // WithContext creates contextual logger entries
 func (l *Logger) WithContext() *logrus.Entry {
+	config := l.getConfig()
 	entry := l.Logger.WithFields(logrus.Fields{
-		"service":     l.config.ServiceName,
-		"environment": l.config.Environment,
+		"service":     config.ServiceName,
+		"environment": config.Environment,
 	})
 
-	if l.config.EnableCaller {
-		if pc, file, line, ok := runtime.Caller(1); ok {
+	if config.EnableCaller {
+		if pc, file, line, ok := runtime.Caller(1 + config.CallerSkip); ok {
 			funcName := runtime.FuncForPC(pc).Name()
 			entry = entry.WithFields(logrus.Fields{
 				"caller_func": filepath.Base(funcName),
@@ -239,9 +640,8 @@ func (l *Logger) LogError(err error, context string, fields map[string]interface
 // Performance logging
 func (l *Logger) LogPerformance(operation string, duration time.Duration, fields map[string]interface{}) {
 	logFields := map[string]interface{}{
-		"operation":   operation,
-		"duration_ms": duration.Milliseconds(),
-		"type":        "performance",
+		"operation": operation,
+		"type":      "performance",
 	}
 
 	// Merge additional fields
@@ -259,7 +659,7 @@ func (l *Logger) LogPerformance(operation string, duration time.Duration, fields
 		level = logrus.DebugLevel
 	}
 
-	l.WithFields(logFields).Log(level, fmt.Sprintf("Operation completed: %s", operation))
+	l.WithDuration("duration", duration).WithFields(logFields).Log(level, fmt.Sprintf("Operation completed: %s", operation))
 }
 
 // SetOutput changes the output destination
@@ -274,13 +674,63 @@ func (l *Logger) SetLevel(level LogLevel) error {
 		return err
 	}
 	l.Logger.SetLevel(logrusLevel)
+	l.mu.Lock()
 	l.config.Level = level
+	l.mu.Unlock()
 	return nil
 }
 
-// GetLevel returns current log level
+// WithTempLevel sets the level and returns a function restoring the previous one,
+// typically deferred. The change applies to the shared logrus logger, so other
+// goroutines and derived loggers see it until restore is called. An invalid
+// level leaves the logger unchanged.
+func (l *Logger) WithTempLevel(level LogLevel) (restore func()) {
+	previous := l.GetLevel()
+	if err := l.SetLevel(level); err != nil {
+		return func() {}
+	}
+	return func() {
+		_ = l.SetLevel(previous)
+	}
+}
+
+// SetCallerSkip sets how many extra frames to skip when reporting the caller,
+// for use when this logger is called through another logging wrapper
+func (l *Logger) SetCallerSkip(skip int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.config.CallerSkip = skip
+	if l.callerHook == nil {
+		l.callerHook = newCallerSkipHook(skip)
+		l.Logger.AddHook(l.callerHook)
+		return
+	}
+	l.callerHook.skip.Store(int64(skip))
+}
+
+// OnFatal registers a handler that runs before the process exits on Fatal.
+// Handlers run in reverse order of registration, like deferred calls, and the
+// exit still happens if a handler panics. Setting Logger.ExitFunc afterwards
+// discards previously registered handlers.
+func (l *Logger) OnFatal(handler func()) {
+	exit := l.Logger.ExitFunc
+	if exit == nil {
+		exit = os.Exit
+	}
+	l.Logger.ExitFunc = func(code int) {
+		defer exit(code)
+		handler()
+	}
+}
+
+// GetLevel returns current log level, shared with every logger derived from l
 func (l *Logger) GetLevel() LogLevel {
-	return l.config.Level
+	level, err := ParseLevel(l.Logger.GetLevel().String())
+	if err != nil {
+		return l.getConfig().Level
+	}
+	return level
 }
 
 // IsLevelEnabled checks if a log level is enabled
@@ -348,3 +798,53 @@ func (l *Logger) PanicWithContext(msg string, fields ...map[string]interface{})
 	}
 	entry.Panic(msg)
 }
+
+// logrusPackage is the function name prefix of frames inside logrus
+const logrusPackage = "github.com/sirupsen/logrus."
+
+// callerSkipHook moves the reported caller past wrapper frames
+type callerSkipHook struct {
+	skip atomic.Int64
+}
+
+// newCallerSkipHook creates a new caller skip hook
+func newCallerSkipHook(skip int) *callerSkipHook {
+	hook := &callerSkipHook{}
+	hook.skip.Store(int64(skip))
+	return hook
+}
+
+// Levels returns the levels this hook should be fired for
+func (h *callerSkipHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire replaces the caller found by logrus with the frame skip frames above it
+func (h *callerSkipHook) Fire(entry *logrus.Entry) error {
+	skip := int(h.skip.Load())
+	if entry.Caller == nil || skip <= 0 {
+		return nil
+	}
+
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+
+	// Walk to the caller logrus reported, past any hook wrapping this one,
+	// then skip further
+	seenCaller := false
+	for {
+		frame, more := frames.Next()
+		if !seenCaller {
+			seenCaller = frame.Function == entry.Caller.Function && frame.Line == entry.Caller.Line
+		} else {
+			skip--
+			if skip == 0 {
+				entry.Caller = &frame
+				return nil
+			}
+		}
+		if !more {
+			return nil
+		}
+	}
+}
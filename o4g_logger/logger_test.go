@@ -2,11 +2,19 @@ package o4g_logger
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"io"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/l00pss/helpme/o4g_logger/o4g_loggertest"
+	"github.com/sirupsen/logrus"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -35,6 +43,56 @@ func TestDefaultConfig(t *testing.T) {
 	}
 }
 
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected LogLevel
+		wantErr  bool
+	}{
+		{"info", InfoLevel, false},
+		{"DEBUG", DebugLevel, false},
+		{" Error ", ErrorLevel, false},
+		{"warn", WarnLevel, false},
+		{"warning", WarnLevel, false},
+		{"WARNING", WarnLevel, false},
+		{"verbose", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			level, err := ParseLevel(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for %q", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if level != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, level)
+			}
+		})
+	}
+}
+
+func TestLogLevelLess(t *testing.T) {
+	ordered := []LogLevel{TraceLevel, DebugLevel, InfoLevel, WarnLevel, ErrorLevel, FatalLevel, PanicLevel}
+	for i := 1; i < len(ordered); i++ {
+		if !ordered[i-1].Less(ordered[i]) {
+			t.Errorf("Expected %v to be less than %v", ordered[i-1], ordered[i])
+		}
+		if ordered[i].Less(ordered[i-1]) {
+			t.Errorf("Expected %v not to be less than %v", ordered[i], ordered[i-1])
+		}
+	}
+	if InfoLevel.Less(InfoLevel) {
+		t.Error("A level should not be less than itself")
+	}
+}
+
 func TestNewLogger(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -148,6 +206,36 @@ func TestNewLoggerFileOutput(t *testing.T) {
 	}
 }
 
+func TestNewNoOpLogger(t *testing.T) {
+	logger := NewNoOpLogger()
+	if logger == nil {
+		t.Fatal("Expected logger but got nil")
+	}
+	if logger.Out != io.Discard {
+		t.Error("Expected output to be io.Discard")
+	}
+	if logger.IsLevelEnabled(ErrorLevel) {
+		t.Error("Expected error level to be disabled")
+	}
+
+	var buf bytes.Buffer
+	logger.AddHook(NewHook(&buf, logrus.TraceLevel))
+
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("No-op logger panicked: %v", r)
+		}
+	}()
+
+	logger.Info("Info message")
+	logger.WithField("key", "value").Error("Error message")
+	logger.LogHTTPRequest("GET", "/", "agent", "127.0.0.1", 200, 10)
+
+	if buf.Len() != 0 {
+		t.Errorf("Expected nothing to be written, got %q", buf.String())
+	}
+}
+
 func TestLoggerMethods(t *testing.T) {
 	var buf bytes.Buffer
 
@@ -264,6 +352,41 @@ func TestLoggerWithField(t *testing.T) {
 	}
 }
 
+func TestLoggerWith(t *testing.T) {
+	var buf bytes.Buffer
+
+	config := DefaultConfig()
+	config.Format = JSONFormat
+
+	logger, err := NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+
+	logger.SetOutput(&buf)
+
+	logger.With("user", 1, "action", "x").Info("Processing request")
+
+	output := buf.String()
+	if !strings.Contains(output, `"user":1`) {
+		t.Errorf("Expected field user not found: %s", output)
+	}
+	if !strings.Contains(output, `"action":"x"`) {
+		t.Errorf("Expected field action not found: %s", output)
+	}
+
+	buf.Reset()
+	logger.With("user", 1, "dangling").Info("Odd arguments")
+
+	output = buf.String()
+	if !strings.Contains(output, "odd number of arguments") {
+		t.Errorf("Expected warning for odd arguments: %s", output)
+	}
+	if !strings.Contains(output, "Odd arguments") {
+		t.Errorf("Expected entry to still be logged: %s", output)
+	}
+}
+
 func TestLoggerWithError(t *testing.T) {
 	var buf bytes.Buffer
 
@@ -312,6 +435,175 @@ func TestSetLevel(t *testing.T) {
 	}
 }
 
+func TestSetLevelPropagatesToDerivedLoggers(t *testing.T) {
+	var buf bytes.Buffer
+
+	config := DefaultConfig()
+	config.Format = JSONFormat
+	config.ServiceName = "derived-service"
+
+	logger, err := NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.SetOutput(&buf)
+
+	derived, _ := WithRequestID(ToContext(context.Background(), logger), "req-1")
+	entry := logger.WithField("component", "worker")
+
+	if derived.config.ServiceName != "derived-service" {
+		t.Errorf("Expected derived logger to inherit config from its parent, got '%s'", derived.config.ServiceName)
+	}
+
+	if err := logger.SetLevel(ErrorLevel); err != nil {
+		t.Fatalf("Unexpected error setting level: %v", err)
+	}
+
+	if derived.GetLevel() != ErrorLevel {
+		t.Errorf("Expected derived level %v, got %v", ErrorLevel, derived.GetLevel())
+	}
+
+	derived.Info("derived info")
+	entry.Info("entry info")
+	if buf.Len() != 0 {
+		t.Errorf("Expected info to be suppressed after SetLevel, got: %s", buf.String())
+	}
+
+	if err := derived.SetLevel(WarnLevel); err != nil {
+		t.Fatalf("Unexpected error setting level: %v", err)
+	}
+	if logger.GetLevel() != WarnLevel {
+		t.Errorf("Expected parent level %v after derived SetLevel, got %v", WarnLevel, logger.GetLevel())
+	}
+}
+
+func TestWithTempLevel(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger, err := NewLogger(DefaultConfig())
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.SetOutput(&buf)
+
+	func() {
+		restore := logger.WithTempLevel(DebugLevel)
+		defer restore()
+
+		if logger.GetLevel() != DebugLevel {
+			t.Errorf("Expected level %v inside scope, got %v", DebugLevel, logger.GetLevel())
+		}
+		logger.Debug("inside scope")
+	}()
+
+	if logger.GetLevel() != InfoLevel {
+		t.Errorf("Expected level %v after restore, got %v", InfoLevel, logger.GetLevel())
+	}
+	if !strings.Contains(buf.String(), "inside scope") {
+		t.Error("Expected debug message to be logged inside scope")
+	}
+
+	restore := logger.WithTempLevel("invalid")
+	restore()
+	if logger.GetLevel() != InfoLevel {
+		t.Errorf("Expected invalid level to leave %v, got %v", InfoLevel, logger.GetLevel())
+	}
+}
+
+func TestLoggerClone(t *testing.T) {
+	var buf bytes.Buffer
+
+	config := DefaultConfig()
+	config.Format = JSONFormat
+	config.ServiceName = "parent-service"
+
+	logger, err := NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.SetOutput(&buf)
+
+	child := logger.WithServiceName("child-service")
+	child.InfoWithContext("from child")
+	logger.InfoWithContext("from parent")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected both loggers to write to the same buffer, got: %s", buf.String())
+	}
+	if !strings.Contains(lines[0], `"service":"child-service"`) {
+		t.Errorf("Expected clone to report the new service name: %s", lines[0])
+	}
+	if !strings.Contains(lines[1], `"service":"parent-service"`) {
+		t.Errorf("Expected parent to keep its service name: %s", lines[1])
+	}
+
+	if err := child.SetLevel(ErrorLevel); err != nil {
+		t.Fatalf("Unexpected error setting level: %v", err)
+	}
+	if logger.GetLevel() != InfoLevel {
+		t.Errorf("Expected clone level change not to affect the parent, got %v", logger.GetLevel())
+	}
+}
+
+func TestLoggerCloneCallerSkipIndependent(t *testing.T) {
+	config := DefaultConfig()
+	config.CallerSkip = 1
+	logger, err := NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.EnableRateLimit(time.Hour)
+
+	clone := logger.Clone()
+	clone.SetCallerSkip(5)
+
+	if skip := logger.callerHook.skip.Load(); skip != 1 {
+		t.Errorf("Expected clone SetCallerSkip not to affect the original, got skip %d", skip)
+	}
+	if skip := clone.callerHook.skip.Load(); skip != 5 {
+		t.Errorf("Expected clone skip 5, got %d", skip)
+	}
+
+	limited := clone.rateLimited()
+	if limited == nil || limited == logger.rateLimited() {
+		t.Fatal("Expected the clone to get its own rate limited hooks")
+	}
+	for _, hook := range limited.hooks[logrus.InfoLevel] {
+		if hook == logger.callerHook {
+			t.Error("Expected the clone's hooks not to contain the original caller hook")
+		}
+	}
+}
+
+func TestLoggerWithServiceNameText(t *testing.T) {
+	var buf bytes.Buffer
+
+	config := DefaultConfig()
+	config.EnableColors = false
+	config.ServiceName = "parent-service"
+
+	logger, err := NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.SetOutput(&buf)
+
+	logger.WithServiceName("child-service").WithField("component", "db").Info("from child")
+
+	if !strings.Contains(buf.String(), "child-service.db") {
+		t.Errorf("Expected text output to use the new service name: %s", buf.String())
+	}
+
+	buf.Reset()
+	logger.EnableRateLimit(time.Hour)
+	logger.WithServiceName("limited-service").WithField("component", "db").Info("from limited child")
+
+	if !strings.Contains(buf.String(), "limited-service.db") {
+		t.Errorf("Expected the new service name with the rate limit enabled: %s", buf.String())
+	}
+}
+
 func TestIsLevelEnabled(t *testing.T) {
 	logger, err := NewLogger(DefaultConfig())
 	if err != nil {
@@ -489,6 +781,549 @@ func TestLogPerformance(t *testing.T) {
 	}
 }
 
+func TestWithDuration(t *testing.T) {
+	var buf bytes.Buffer
+
+	config := DefaultConfig()
+	config.Format = JSONFormat
+
+	logger, err := NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+
+	logger.SetOutput(&buf)
+
+	logger.WithDuration("latency", 1500*time.Millisecond).Info("Request served")
+
+	output := buf.String()
+	if !strings.Contains(output, `"latency_ms":1500`) {
+		t.Errorf("Expected latency_ms field in output: %s", output)
+	}
+	if !strings.Contains(output, `"latency_human":"1.5s"`) {
+		t.Errorf("Expected latency_human field in output: %s", output)
+	}
+
+	buf.Reset()
+	logger.LogPerformance("slow_query", 2*time.Second, nil)
+
+	output = buf.String()
+	if !strings.Contains(output, `"duration_ms":2000`) || !strings.Contains(output, `"duration_human":"2s"`) {
+		t.Errorf("Expected LogPerformance to use both duration forms: %s", output)
+	}
+}
+
+func TestOnFatal(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger, err := NewLogger(DefaultConfig())
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+
+	logger.SetOutput(&buf)
+
+	var calls []string
+	exitCode := -1
+	logger.Logger.ExitFunc = func(code int) {
+		calls = append(calls, "exit")
+		exitCode = code
+	}
+	logger.OnFatal(func() { calls = append(calls, "first") })
+	logger.OnFatal(func() { calls = append(calls, "second") })
+
+	logger.Fatal("Fatal error")
+
+	expected := []string{"second", "first", "exit"}
+	if strings.Join(calls, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected calls %v, got %v", expected, calls)
+	}
+	if exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+	}
+	if !strings.Contains(buf.String(), "Fatal error") {
+		t.Error("Expected fatal message to be logged before exit")
+	}
+}
+
+func TestDefaultFields(t *testing.T) {
+	var buf bytes.Buffer
+
+	config := DefaultConfig()
+	config.Format = JSONFormat
+	config.DefaultFields = map[string]interface{}{
+		"version": "1.2.3",
+		"region":  "eu-west-1",
+	}
+
+	logger, err := NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+
+	logger.SetOutput(&buf)
+
+	logger.WithField("region", "us-east-1").Info("Default fields")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+
+	if entry["version"] != "1.2.3" {
+		t.Errorf("Expected default version field, got %v", entry["version"])
+	}
+	if entry["region"] != "us-east-1" {
+		t.Errorf("Expected call-site region to win, got %v", entry["region"])
+	}
+
+	buf.Reset()
+	logger.Info("Plain message")
+
+	if !strings.Contains(buf.String(), `"region":"eu-west-1"`) {
+		t.Errorf("Expected default region in output: %s", buf.String())
+	}
+}
+
+func TestNestFieldsUnder(t *testing.T) {
+	var buf bytes.Buffer
+
+	config := DefaultConfig()
+	config.Format = JSONFormat
+	config.NestFieldsUnder = "fields"
+
+	logger, err := NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+
+	logger.SetOutput(&buf)
+
+	logger.WithFields(map[string]interface{}{
+		"user_id": "12345",
+		"level":   "custom",
+	}).Info("Nested fields")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+
+	for _, key := range []string{"timestamp", "level", "message"} {
+		if _, ok := entry[key]; !ok {
+			t.Errorf("Expected reserved key %s at top level: %s", key, buf.String())
+		}
+	}
+	if entry["level"] != "info" {
+		t.Errorf("Expected top-level level to be info, got %v", entry["level"])
+	}
+	if _, ok := entry["user_id"]; ok {
+		t.Error("Custom fields should not be at top level")
+	}
+
+	nested, ok := entry["fields"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected nested fields object: %s", buf.String())
+	}
+	if nested["user_id"] != "12345" {
+		t.Errorf("Expected nested user_id 12345, got %v", nested["user_id"])
+	}
+	if nested["level"] != "custom" {
+		t.Errorf("Expected nested level custom, got %v", nested["level"])
+	}
+}
+
+func TestRedactFields(t *testing.T) {
+	for _, format := range []OutputFormat{TextFormat, JSONFormat} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+
+			config := DefaultConfig()
+			config.Format = format
+			config.EnableColors = false
+			config.RedactFields = []string{"password"}
+			config.RedactPatterns = []*regexp.Regexp{regexp.MustCompile(`(?i)token`)}
+
+			logger, err := NewLogger(config)
+			if err != nil {
+				t.Fatalf("Failed to create logger: %v", err)
+			}
+
+			logger.SetOutput(&buf)
+
+			logger.WithFields(map[string]interface{}{
+				"user":         "alice",
+				"password":     "hunter2",
+				"access_token": "abc123",
+			}).Info("User login")
+
+			output := buf.String()
+			if strings.Contains(output, "hunter2") {
+				t.Errorf("Expected password to be redacted: %s", output)
+			}
+			if strings.Contains(output, "abc123") {
+				t.Errorf("Expected access_token to be redacted: %s", output)
+			}
+			if !strings.Contains(output, RedactedValue) {
+				t.Errorf("Expected redacted marker in output: %s", output)
+			}
+			if !strings.Contains(output, "alice") {
+				t.Errorf("Expected non-sensitive field in output: %s", output)
+			}
+		})
+	}
+}
+
+// logThroughWrapper stands in for a logging library built on top of Logger
+func logThroughWrapper(logger *Logger, msg string) {
+	logger.Info(msg)
+}
+
+// contextThroughWrapper stands in for a wrapper using WithContext
+func contextThroughWrapper(logger *Logger, msg string) {
+	logger.WithContext().Info(msg)
+}
+
+func TestTimeZone(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("Time zone database unavailable: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		timeZone string
+		format   OutputFormat
+		location *time.Location
+	}{
+		{"UTC JSON", "UTC", JSONFormat, time.UTC},
+		{"UTC text", "UTC", TextFormat, time.UTC},
+		{"New York JSON", "America/New_York", JSONFormat, newYork},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			config := DefaultConfig()
+			config.Format = tt.format
+			config.EnableColors = false
+			config.TimestampFormat = time.RFC3339
+			config.TimeZone = tt.timeZone
+
+			logger, err := NewLogger(config)
+			if err != nil {
+				t.Fatalf("Failed to create logger: %v", err)
+			}
+			logger.SetOutput(&buf)
+
+			logger.Info("zoned")
+
+			// The zone offset is the same for any time within the test run
+			offset := time.Now().In(tt.location).Format("Z07:00")
+			if !strings.Contains(buf.String(), offset) {
+				t.Errorf("Expected timestamp with offset %s, got: %s", offset, buf.String())
+			}
+		})
+	}
+}
+
+func TestTimeZoneInvalid(t *testing.T) {
+	config := DefaultConfig()
+	config.TimeZone = "Mars/Olympus_Mons"
+
+	if _, err := NewLogger(config); err == nil {
+		t.Error("Expected error for unknown time zone")
+	}
+}
+
+func TestDebugCollection(t *testing.T) {
+	logger, hook := newMemoryLogger(t)
+	logger.SetLevel(DebugLevel)
+
+	items := make([]int, 1000)
+	for i := range items {
+		items[i] = i
+	}
+	logger.DebugCollection("ids", items, 5)
+
+	entries := hook.Entries()
+	if len(entries) != 1 || entries[0].Level != logrus.DebugLevel {
+		t.Fatalf("Expected 1 debug entry, got %v", entries)
+	}
+	data := entries[0].Data
+	if shown, ok := data["ids"].([]interface{}); !ok || len(shown) != 5 || shown[4] != 4 {
+		t.Errorf("Expected the first 5 items, got %v", data["ids"])
+	}
+	if data["total"] != 1000 {
+		t.Errorf("Expected total 1000, got %v", data["total"])
+	}
+	if data["truncated"] != true {
+		t.Errorf("Expected truncated marker, got %v", data["truncated"])
+	}
+
+	hook.Reset()
+	logger.DebugCollection("roles", map[string]int{"b": 2, "a": 1}, 5)
+	data = hook.Entries()[0].Data
+	if shown, ok := data["roles"].(map[string]interface{}); !ok || len(shown) != 2 || shown["a"] != 1 {
+		t.Errorf("Expected all map items, got %v", data["roles"])
+	}
+	if _, ok := data["truncated"]; ok || data["total"] != 2 {
+		t.Errorf("Expected no truncation for a small map, got %v", data)
+	}
+
+	hook.Reset()
+	logger.SetLevel(InfoLevel)
+	logger.DebugCollection("ids", items, 5)
+	if len(hook.Entries()) != 0 {
+		t.Error("Expected nothing to be logged above debug level")
+	}
+}
+
+func TestReplaceFormatter(t *testing.T) {
+	var buf bytes.Buffer
+
+	config := DefaultConfig()
+	config.Level = WarnLevel
+	config.EnableColors = false
+	logger, err := NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.SetOutput(&buf)
+	hook := o4g_loggertest.NewMemoryHook()
+	logger.AddHook(hook)
+
+	logger.Warn("Text line")
+	if json.Valid(buf.Bytes()) {
+		t.Fatalf("Expected text output before replacing the formatter, got: %s", buf.String())
+	}
+
+	buf.Reset()
+	logger.ReplaceFormatter(Config{Format: JSONFormat, ServiceName: "switched"})
+
+	logger.Info("Filtered by level")
+	logger.Warn("JSON line")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Expected a single JSON line after replacing the formatter, got %q: %v", buf.String(), err)
+	}
+	if entry["message"] != "JSON line" {
+		t.Errorf("Unexpected JSON entry: %s", buf.String())
+	}
+	if hook.Count(logrus.WarnLevel) != 2 {
+		t.Errorf("Expected hooks to be preserved, got %d warn entries", hook.Count(logrus.WarnLevel))
+	}
+	if logger.config.Format != JSONFormat || logger.config.TimestampFormat != time.RFC3339 {
+		t.Errorf("Expected config to be updated with current timestamp format kept, got %+v", logger.config)
+	}
+}
+
+func TestReplaceFormatterPartialConfig(t *testing.T) {
+	var buf bytes.Buffer
+
+	config := DefaultConfig()
+	config.EnableCaller = false
+	config.ServiceName = "billing"
+	logger, err := NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.SetOutput(&buf)
+
+	logger.ReplaceFormatter(Config{Format: JSONFormat})
+	if logger.config.ServiceName != "billing" || logger.config.Environment != "development" || !logger.config.EnableColors {
+		t.Errorf("Expected zero fields to keep the current config, got %+v", logger.config)
+	}
+
+	logger.Info("Without caller")
+	if strings.Contains(buf.String(), `"function"`) {
+		t.Errorf("Expected no caller before enabling it, got: %s", buf.String())
+	}
+
+	buf.Reset()
+	logger.ReplaceFormatter(Config{EnableCaller: true})
+	logger.Info("With caller")
+	if !logger.Logger.ReportCaller || !strings.Contains(buf.String(), `"function"`) {
+		t.Errorf("Expected ReportCaller to follow EnableCaller, got: %s", buf.String())
+	}
+}
+
+func TestTrimCallerPath(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/app\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		file     string
+		expected string
+	}{
+		{"module cache", "/home/user/go/pkg/mod/github.com/sirupsen/logrus@v1.9.3/hooks/syslog/syslog.go", "hooks/syslog/syslog.go"},
+		{"module root", filepath.Join(root, "internal", "handler", "user.go"), "internal/handler/user.go"},
+		{"pseudo-version", "/go/pkg/mod/golang.org/x/sys@v0.0.0-20220715151400-c0bba94af5f8/unix/syscall.go", "unix/syscall.go"},
+		{"outside any module", "/nonexistent/src/main.go", "/nonexistent/src/main.go"},
+		{"at sign in directory", "/home/user@corp/src/main.go", "/home/user@corp/src/main.go"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := trimCallerPath(tt.file); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTrimCallerPathJSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	config := DefaultConfig()
+	config.Format = JSONFormat
+	config.TrimCallerPath = true
+
+	logger, err := NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.SetOutput(&buf)
+
+	logger.Info("Trimmed caller")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if file, _ := entry["file"].(string); !strings.HasPrefix(file, "logger_test.go:") {
+		t.Errorf("Expected module-relative caller file, got %v", entry["file"])
+	}
+	if function, _ := entry["function"].(string); !strings.HasSuffix(function, "TestTrimCallerPathJSON") {
+		t.Errorf("Expected caller function to be kept, got %v", entry["function"])
+	}
+}
+
+func TestStructCaller(t *testing.T) {
+	var buf bytes.Buffer
+
+	config := DefaultConfig()
+	config.Format = JSONFormat
+	config.EnableCaller = true
+	config.StructCaller = true
+
+	logger, err := NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.SetOutput(&buf)
+
+	logger.WithField("user_id", "12345").Info("Structured caller")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+
+	caller, ok := entry[CallerField].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected nested caller object, got: %s", buf.String())
+	}
+	if file, _ := caller["file"].(string); !strings.HasSuffix(file, "logger_test.go") {
+		t.Errorf("Expected caller.file to be the test file, got %v", caller["file"])
+	}
+	if line, _ := caller["line"].(float64); line <= 0 {
+		t.Errorf("Expected positive caller.line, got %v", caller["line"])
+	}
+	if function, _ := caller["function"].(string); !strings.HasSuffix(function, "TestStructCaller") {
+		t.Errorf("Expected caller.function to be the test, got %v", caller["function"])
+	}
+	for _, key := range []string{"file", "function"} {
+		if _, ok := entry[key]; ok {
+			t.Errorf("Expected flat %s key to be omitted, got: %s", key, buf.String())
+		}
+	}
+	if entry["user_id"] != "12345" {
+		t.Errorf("Expected fields to be kept, got: %s", buf.String())
+	}
+}
+
+func TestCallerSkip(t *testing.T) {
+	var buf bytes.Buffer
+
+	config := DefaultConfig()
+	config.Format = JSONFormat
+	config.CallerSkip = 1
+
+	logger, err := NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.SetOutput(&buf)
+
+	logThroughWrapper(logger, "through wrapper")
+	output := buf.String()
+	if !strings.Contains(output, "TestCallerSkip") {
+		t.Errorf("Expected caller to be the test, got: %s", output)
+	}
+	if strings.Contains(output, "logThroughWrapper") {
+		t.Errorf("Expected wrapper frame to be skipped, got: %s", output)
+	}
+
+	buf.Reset()
+	contextThroughWrapper(logger, "through context wrapper")
+	output = buf.String()
+	if !strings.Contains(output, `"caller_func":"o4g_logger.TestCallerSkip"`) {
+		t.Errorf("Expected WithContext caller to be the test, got: %s", output)
+	}
+}
+
+func TestCallerSkipWithRateLimit(t *testing.T) {
+	var buf bytes.Buffer
+
+	config := DefaultConfig()
+	config.Format = JSONFormat
+	config.CallerSkip = 1
+
+	logger, err := NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.SetOutput(&buf)
+	logger.EnableRateLimit(time.Minute)
+
+	logThroughWrapper(logger, "through wrapper")
+	output := buf.String()
+	if !strings.Contains(output, "TestCallerSkipWithRateLimit") || strings.Contains(output, "logThroughWrapper") {
+		t.Errorf("Expected caller to be the test with the rate limit enabled, got: %s", output)
+	}
+}
+
+func TestSetCallerSkip(t *testing.T) {
+	var buf bytes.Buffer
+
+	config := DefaultConfig()
+	config.Format = JSONFormat
+
+	logger, err := NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.SetOutput(&buf)
+
+	logThroughWrapper(logger, "without skip")
+	if !strings.Contains(buf.String(), "logThroughWrapper") {
+		t.Errorf("Expected wrapper to be reported without skip, got: %s", buf.String())
+	}
+
+	buf.Reset()
+	logger.SetCallerSkip(1)
+	logThroughWrapper(logger, "with skip")
+	if !strings.Contains(buf.String(), "TestSetCallerSkip") {
+		t.Errorf("Expected caller to be the test after SetCallerSkip, got: %s", buf.String())
+	}
+}
+
 // Benchmark tests
 func BenchmarkLoggerInfo(b *testing.B) {
 	logger, _ := NewLogger(DefaultConfig())
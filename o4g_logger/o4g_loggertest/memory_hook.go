@@ -0,0 +1,82 @@
+// Package o4g_loggertest provides test helpers for code logging with o4g_logger
+package o4g_loggertest
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+// MemoryHook records fired entries in memory, mainly for assertions in tests
+type MemoryHook struct {
+	mu      sync.Mutex
+	entries []logrus.Entry
+}
+
+// NewMemoryHook creates a new memory hook
+func NewMemoryHook() *MemoryHook {
+	return &MemoryHook{}
+}
+
+// Levels returns the levels this hook should be fired for
+func (h *MemoryHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire records a copy of the entry
+func (h *MemoryHook) Fire(entry *logrus.Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	recorded := *entry
+	recorded.Data = make(logrus.Fields, len(entry.Data))
+	for k, v := range entry.Data {
+		recorded.Data[k] = v
+	}
+	h.entries = append(h.entries, recorded)
+	return nil
+}
+
+// Entries returns the recorded entries in the order they were fired
+func (h *MemoryHook) Entries() []logrus.Entry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	entries := make([]logrus.Entry, len(h.entries))
+	copy(entries, h.entries)
+	return entries
+}
+
+// Count returns how many entries were recorded at exactly level
+func (h *MemoryHook) Count(level logrus.Level) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	count := 0
+	for _, entry := range h.entries {
+		if entry.Level == level {
+			count++
+		}
+	}
+	return count
+}
+
+// Reset discards the recorded entries
+func (h *MemoryHook) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.entries = nil
+}
+
+// AssertNoLevel fails t for every recorded entry at level or more severe
+func (h *MemoryHook) AssertNoLevel(t testing.TB, level logrus.Level) {
+	t.Helper()
+
+	for _, entry := range h.Entries() {
+		if entry.Level <= level {
+			t.Errorf("unexpected %s log: %s", entry.Level, entry.Message)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package o4g_loggertest
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/l00pss/helpme/o4g_logger"
+	"github.com/sirupsen/logrus"
+)
+
+// recordingTB captures failures instead of failing the running test
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, format)
+}
+
+func newMemoryLogger(t *testing.T) (*o4g_logger.Logger, *MemoryHook) {
+	logger, err := o4g_logger.NewLogger(o4g_logger.DefaultConfig())
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.SetOutput(io.Discard)
+
+	hook := NewMemoryHook()
+	logger.AddHook(hook)
+	return logger, hook
+}
+
+func TestMemoryHookCount(t *testing.T) {
+	logger, hook := newMemoryLogger(t)
+
+	logger.Info("first")
+	logger.WithField("attempt", 2).Info("second")
+	logger.Warn("careful")
+
+	if hook.Count(logrus.InfoLevel) != 2 {
+		t.Errorf("Expected 2 info entries, got %d", hook.Count(logrus.InfoLevel))
+	}
+	if hook.Count(logrus.WarnLevel) != 1 {
+		t.Errorf("Expected 1 warn entry, got %d", hook.Count(logrus.WarnLevel))
+	}
+	if entries := hook.Entries(); len(entries) != 3 || entries[1].Data["attempt"] != 2 {
+		t.Errorf("Expected entries to be recorded with their fields, got %v", entries)
+	}
+
+	hook.Reset()
+	if len(hook.Entries()) != 0 {
+		t.Error("Expected Reset to discard recorded entries")
+	}
+}
+
+func TestMemoryHookAssertNoLevel(t *testing.T) {
+	logger, hook := newMemoryLogger(t)
+
+	logger.Info("all good")
+	logger.Warn("just a warning")
+	hook.AssertNoLevel(t, logrus.ErrorLevel)
+
+	logger.Error("accidental error")
+
+	recorder := &recordingTB{TB: t}
+	hook.AssertNoLevel(recorder, logrus.ErrorLevel)
+
+	if len(recorder.errors) != 1 {
+		t.Fatalf("Expected AssertNoLevel to report 1 entry, got %d", len(recorder.errors))
+	}
+	if !strings.Contains(recorder.errors[0], "unexpected") {
+		t.Errorf("Expected failure message, got %q", recorder.errors[0])
+	}
+}
@@ -0,0 +1,15 @@
+module github.com/l00pss/helpme/o4g_logger/otellog
+
+go 1.25
+
+require (
+	github.com/l00pss/helpme/o4g_logger v0.1.0
+	github.com/sirupsen/logrus v1.9.3
+	go.opentelemetry.io/otel/trace v1.41.0
+)
+
+require (
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	go.opentelemetry.io/otel v1.41.0 // indirect
+	golang.org/x/sys v0.37.0 // indirect
+)
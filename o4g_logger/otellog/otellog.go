@@ -0,0 +1,62 @@
+// Package otellog attaches OpenTelemetry trace and span ids to log entries.
+// It lives in its own package so that only callers using OpenTelemetry
+// depend on go.opentelemetry.io/otel.
+package otellog
+
+import (
+	"context"
+
+	"github.com/l00pss/helpme/o4g_logger"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// TraceIDKey is the field key for the trace id
+	TraceIDKey = "trace_id"
+	// SpanIDKey is the field key for the span id
+	SpanIDKey = "span_id"
+)
+
+// Fields returns the trace and span ids of the span in ctx, or nil if there is none
+func Fields(ctx context.Context) map[string]interface{} {
+	if ctx == nil {
+		return nil
+	}
+
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return nil
+	}
+
+	return map[string]interface{}{
+		TraceIDKey: spanContext.TraceID().String(),
+		SpanIDKey:  spanContext.SpanID().String(),
+	}
+}
+
+// WithTrace creates a new logger entry with the trace and span ids from ctx
+func WithTrace(ctx context.Context, logger *o4g_logger.Logger) *logrus.Entry {
+	return logger.Logger.WithContext(ctx).WithFields(logrus.Fields(Fields(ctx)))
+}
+
+// Hook adds trace and span ids to every entry logged with a context
+type Hook struct{}
+
+// NewHook creates a new trace hook
+func NewHook() *Hook {
+	return &Hook{}
+}
+
+// Levels returns the levels this hook should be fired for
+func (h *Hook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire adds the trace and span ids from the entry context
+func (h *Hook) Fire(entry *logrus.Entry) error {
+	for k, v := range Fields(entry.Context) {
+		entry.Data[k] = v
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package otellog
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/l00pss/helpme/o4g_logger"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func spanContext(t *testing.T) context.Context {
+	t.Helper()
+
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	if err != nil {
+		t.Fatalf("Failed to parse trace id: %v", err)
+	}
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	if err != nil {
+		t.Fatalf("Failed to parse span id: %v", err)
+	}
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+	return trace.ContextWithSpanContext(context.Background(), sc)
+}
+
+func TestFields(t *testing.T) {
+	fields := Fields(spanContext(t))
+	if fields[TraceIDKey] != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("Expected trace id, got %v", fields[TraceIDKey])
+	}
+	if fields[SpanIDKey] != "00f067aa0ba902b7" {
+		t.Errorf("Expected span id, got %v", fields[SpanIDKey])
+	}
+
+	if Fields(context.Background()) != nil {
+		t.Error("Expected no fields without a span")
+	}
+}
+
+func TestWithTrace(t *testing.T) {
+	var buf bytes.Buffer
+
+	config := o4g_logger.DefaultConfig()
+	config.Format = o4g_logger.JSONFormat
+
+	logger, err := o4g_logger.NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+
+	logger.SetOutput(&buf)
+
+	WithTrace(spanContext(t), logger).Info("Traced request")
+
+	output := buf.String()
+	if !strings.Contains(output, `"trace_id":"4bf92f3577b34da6a3ce929d0e0e4736"`) {
+		t.Errorf("Expected trace_id in output: %s", output)
+	}
+	if !strings.Contains(output, `"span_id":"00f067aa0ba902b7"`) {
+		t.Errorf("Expected span_id in output: %s", output)
+	}
+}
+
+func TestHook(t *testing.T) {
+	var buf bytes.Buffer
+
+	config := o4g_logger.DefaultConfig()
+	config.Format = o4g_logger.JSONFormat
+
+	logger, err := o4g_logger.NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+
+	logger.SetOutput(&buf)
+	logger.AddHook(NewHook())
+
+	logger.Logger.WithContext(spanContext(t)).Info("Traced request")
+
+	if !strings.Contains(buf.String(), `"trace_id":"4bf92f3577b34da6a3ce929d0e0e4736"`) {
+		t.Errorf("Expected trace_id in output: %s", buf.String())
+	}
+
+	buf.Reset()
+	logger.Info("Untraced request")
+
+	if strings.Contains(buf.String(), "trace_id") {
+		t.Errorf("Expected no trace_id without a span: %s", buf.String())
+	}
+}
@@ -0,0 +1,57 @@
+//go:build !windows && !nacl && !plan9
+
+package o4g_logger
+
+import (
+	"log/syslog"
+
+	"github.com/sirupsen/logrus"
+)
+
+// syslogHook writes entries to syslog with its own formatter, so the
+// colours of the logger's text formatter never reach syslog
+type syslogHook struct {
+	writer    *syslog.Writer
+	formatter logrus.Formatter
+}
+
+// newSyslogHook connects to the syslog daemon configured by SyslogNetwork and
+// SyslogAddr, tagging entries with the service name. Entries are formatted
+// like the logger's but without colours.
+func newSyslogHook(config Config) (logrus.Hook, error) {
+	writer, err := syslog.Dial(config.SyslogNetwork, config.SyslogAddr, syslog.LOG_INFO|syslog.LOG_USER, config.ServiceName)
+	if err != nil {
+		return nil, err
+	}
+
+	plain := config
+	plain.EnableColors = false
+	return &syslogHook{writer: writer, formatter: newFormatter(plain)}, nil
+}
+
+// Levels returns the levels this hook should be fired for
+func (h *syslogHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire writes the formatted entry with the syslog priority of its level
+func (h *syslogHook) Fire(entry *logrus.Entry) error {
+	line, err := h.formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+
+	message := string(line)
+	switch entry.Level {
+	case logrus.PanicLevel, logrus.FatalLevel:
+		return h.writer.Crit(message)
+	case logrus.ErrorLevel:
+		return h.writer.Err(message)
+	case logrus.WarnLevel:
+		return h.writer.Warning(message)
+	case logrus.InfoLevel:
+		return h.writer.Info(message)
+	default:
+		return h.writer.Debug(message)
+	}
+}
@@ -0,0 +1,10 @@
+//go:build windows || nacl || plan9
+
+package o4g_logger
+
+import "github.com/sirupsen/logrus"
+
+// newSyslogHook reports that syslog is unavailable on this platform
+func newSyslogHook(Config) (logrus.Hook, error) {
+	return nil, ErrSyslogUnsupported
+}
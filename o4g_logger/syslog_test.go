@@ -0,0 +1,93 @@
+//go:build !windows && !nacl && !plan9
+
+package o4g_logger
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newSyslogLogger creates a syslog logger with the default config, sending to a fake UDP syslog server
+func newSyslogLogger(t *testing.T) (*Logger, net.PacketConn) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to start fake syslog server: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	config := DefaultConfig()
+	config.Output = "syslog"
+	config.SyslogNetwork = "udp"
+	config.SyslogAddr = conn.LocalAddr().String()
+	config.ServiceName = "syslog-test"
+
+	logger, err := NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create syslog logger: %v", err)
+	}
+	return logger, conn
+}
+
+// readSyslog returns the next packet received by the fake syslog server
+func readSyslog(conn net.PacketConn, timeout time.Duration) (string, bool) {
+	buf := make([]byte, 4096)
+	conn.SetReadDeadline(time.Now().Add(timeout))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		return "", false
+	}
+	return string(buf[:n]), true
+}
+
+func TestSyslogOutput(t *testing.T) {
+	logger, conn := newSyslogLogger(t)
+
+	logger.Warn("Disk almost full")
+
+	message, ok := readSyslog(conn, 2*time.Second)
+	if !ok {
+		t.Fatal("Failed to read syslog message")
+	}
+	if !strings.Contains(message, "Disk almost full") {
+		t.Errorf("Expected message in syslog packet, got: %s", message)
+	}
+	if !strings.Contains(message, "syslog-test") {
+		t.Errorf("Expected service name tag in syslog packet, got: %s", message)
+	}
+	// LOG_USER|LOG_WARNING
+	if !strings.HasPrefix(message, "<12>") {
+		t.Errorf("Expected warning priority, got: %s", message)
+	}
+}
+
+func TestSyslogOutputHasNoColors(t *testing.T) {
+	logger, conn := newSyslogLogger(t)
+
+	logger.Error("Connection lost")
+
+	message, ok := readSyslog(conn, 2*time.Second)
+	if !ok {
+		t.Fatal("Failed to read syslog message")
+	}
+	if strings.Contains(message, "\x1b[") {
+		t.Errorf("Expected no ANSI colour codes in syslog packet, got: %q", message)
+	}
+}
+
+func TestSyslogOutputRateLimited(t *testing.T) {
+	logger, conn := newSyslogLogger(t)
+	logger.EnableRateLimit(time.Hour)
+
+	for i := 0; i < 10; i++ {
+		logger.Error("Connection lost")
+	}
+
+	if _, ok := readSyslog(conn, 2*time.Second); !ok {
+		t.Fatal("Failed to read syslog message")
+	}
+	if message, ok := readSyslog(conn, 100*time.Millisecond); ok {
+		t.Errorf("Expected suppressed entries not to reach syslog, got: %s", message)
+	}
+}
@@ -4,21 +4,28 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/l00pss/helpme/goerr"
 	"github.com/sirupsen/logrus"
 )
 
-// ContextKey is the type for context keys
-type ContextKey string
+// ContextKey is the type for context keys. It is goerr's key type, so
+// goerr.WrapWithContext keeps the request and user IDs set by this package.
+type ContextKey = goerr.ContextKey
 
 const (
 	// LoggerContextKey is the context key for logger
 	LoggerContextKey ContextKey = "logger"
 	// RequestIDKey is the context key for wrapper ID
-	RequestIDKey ContextKey = "request_id"
+	RequestIDKey = goerr.RequestIDKey
 	// UserIDKey is the context key for user ID
-	UserIDKey ContextKey = "user_id"
+	UserIDKey = goerr.UserIDKey
 )
 
 // FromContext extracts logger from context
@@ -37,15 +44,49 @@ func ToContext(ctx context.Context, logger *Logger) context.Context {
 // WithRequestID adds wrapper ID to context and returns logger with wrapper ID field
 func WithRequestID(ctx context.Context, requestID string) (*Logger, context.Context) {
 	ctx = context.WithValue(ctx, RequestIDKey, requestID)
-	logger := FromContext(ctx).WithField("request_id", requestID)
-	return &Logger{Logger: logger.Logger, config: GetDefaultLogger().config}, ctx
+	parent := FromContext(ctx)
+	logger := parent.WithField("request_id", requestID)
+	return &Logger{Logger: logger.Logger, config: parent.getConfig(), callerHook: parent.callerHook}, ctx
 }
 
 // WithUserID adds user ID to context and returns logger with user ID field
 func WithUserID(ctx context.Context, userID string) (*Logger, context.Context) {
 	ctx = context.WithValue(ctx, UserIDKey, userID)
-	logger := FromContext(ctx).WithField("user_id", userID)
-	return &Logger{Logger: logger.Logger, config: GetDefaultLogger().config}, ctx
+	parent := FromContext(ctx)
+	logger := parent.WithField("user_id", userID)
+	return &Logger{Logger: logger.Logger, config: parent.getConfig(), callerHook: parent.callerHook}, ctx
+}
+
+var (
+	contextFieldsMu sync.RWMutex
+	contextFields   = []ContextKey{RequestIDKey, UserIDKey}
+)
+
+// RegisterContextFields adds context keys whose values CtxLog logs, using the key as field name
+func RegisterContextFields(keys ...ContextKey) {
+	contextFieldsMu.Lock()
+	defer contextFieldsMu.Unlock()
+
+	for _, key := range keys {
+		if !slices.Contains(contextFields, key) {
+			contextFields = append(contextFields, key)
+		}
+	}
+}
+
+// CtxLog returns an entry from the context's logger with a field for every
+// registered context key set in ctx
+func CtxLog(ctx context.Context) *logrus.Entry {
+	contextFieldsMu.RLock()
+	defer contextFieldsMu.RUnlock()
+
+	fields := make(logrus.Fields, len(contextFields))
+	for _, key := range contextFields {
+		if value := ctx.Value(key); value != nil {
+			fields[string(key)] = value
+		}
+	}
+	return FromContext(ctx).WithFields(fields)
 }
 
 // Timer is a utility for measuring operation duration
@@ -77,9 +118,8 @@ func (t *Timer) Stop() time.Duration {
 	duration := time.Since(t.start)
 
 	logFields := map[string]interface{}{
-		"operation":   t.name,
-		"duration_ms": duration.Milliseconds(),
-		"type":        "timer",
+		"operation": t.name,
+		"type":      "timer",
 	}
 
 	// Merge additional fields
@@ -87,7 +127,7 @@ func (t *Timer) Stop() time.Duration {
 		logFields[k] = v
 	}
 
-	t.logger.WithFields(logFields).Info(fmt.Sprintf("Operation completed: %s", t.name))
+	t.logger.WithDuration("duration", duration).WithFields(logFields).Info(fmt.Sprintf("Operation completed: %s", t.name))
 	return duration
 }
 
@@ -96,9 +136,8 @@ func (t *Timer) Stopf(format string, args ...interface{}) time.Duration {
 	duration := time.Since(t.start)
 
 	logFields := map[string]interface{}{
-		"operation":   t.name,
-		"duration_ms": duration.Milliseconds(),
-		"type":        "timer",
+		"operation": t.name,
+		"type":      "timer",
 	}
 
 	// Merge additional fields
@@ -107,14 +146,15 @@ func (t *Timer) Stopf(format string, args ...interface{}) time.Duration {
 	}
 
 	message := fmt.Sprintf(format, args...)
-	t.logger.WithFields(logFields).Info(message)
+	t.logger.WithDuration("duration", duration).WithFields(logFields).Info(message)
 	return duration
 }
 
 // Hook is a custom logrus hook for additional processing
 type Hook struct {
-	Writer io.Writer
-	Level  logrus.Level
+	Writer    io.Writer
+	Level     logrus.Level
+	Formatter logrus.Formatter // nil uses the formatter of the entry's logger
 }
 
 // NewHook creates a new hook
@@ -136,18 +176,413 @@ func (h *Hook) Levels() []logrus.Level {
 	return levels
 }
 
-// Fire is called when a log entry is fired
+// Fire writes the entry formatted like the logger's own output, or with Formatter if set
 func (h *Hook) Fire(entry *logrus.Entry) error {
-	line, err := entry.String()
+	formatter := h.Formatter
+	if formatter == nil {
+		formatter = entry.Logger.Formatter
+	}
+	line, err := formatter.Format(entry)
 	if err != nil {
 		return err
 	}
-	_, err = h.Writer.Write([]byte(line))
+	_, err = h.Writer.Write(line)
 	return err
 }
 
-// AddHook adds a hook to the logger
+// DefaultFieldsHook adds fields to every entry without overriding call-site fields
+type DefaultFieldsHook struct {
+	Fields map[string]interface{}
+}
+
+// NewDefaultFieldsHook creates a new default fields hook
+func NewDefaultFieldsHook(fields map[string]interface{}) *DefaultFieldsHook {
+	return &DefaultFieldsHook{
+		Fields: fields,
+	}
+}
+
+// Levels returns the levels this hook should be fired for
+func (h *DefaultFieldsHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire adds the default fields missing from the entry
+func (h *DefaultFieldsHook) Fire(entry *logrus.Entry) error {
+	for key, value := range h.Fields {
+		if _, exists := entry.Data[key]; !exists {
+			entry.Data[key] = value
+		}
+	}
+	return nil
+}
+
+// RedactedValue replaces the value of redacted fields
+const RedactedValue = "***"
+
+// RedactionHook masks sensitive field values before the entry is written
+type RedactionHook struct {
+	Fields   []string
+	Patterns []*regexp.Regexp
+}
+
+// NewRedactionHook creates a new redaction hook
+func NewRedactionHook(fields []string, patterns []*regexp.Regexp) *RedactionHook {
+	return &RedactionHook{
+		Fields:   fields,
+		Patterns: patterns,
+	}
+}
+
+// Levels returns the levels this hook should be fired for
+func (h *RedactionHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire replaces the values of matching fields with RedactedValue
+func (h *RedactionHook) Fire(entry *logrus.Entry) error {
+	for key := range entry.Data {
+		if h.shouldRedact(key) {
+			entry.Data[key] = RedactedValue
+		}
+	}
+	return nil
+}
+
+// shouldRedact checks if a field key is configured for redaction
+func (h *RedactionHook) shouldRedact(key string) bool {
+	for _, field := range h.Fields {
+		if strings.EqualFold(field, key) {
+			return true
+		}
+	}
+	for _, pattern := range h.Patterns {
+		if pattern.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// TimeZoneHook converts entry timestamps to Location before they are formatted
+type TimeZoneHook struct {
+	Location *time.Location
+}
+
+// NewTimeZoneHook creates a new time zone hook
+func NewTimeZoneHook(location *time.Location) *TimeZoneHook {
+	return &TimeZoneHook{
+		Location: location,
+	}
+}
+
+// Levels returns the levels this hook should be fired for
+func (h *TimeZoneHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire converts the entry time to the hook's location
+func (h *TimeZoneHook) Fire(entry *logrus.Entry) error {
+	entry.Time = entry.Time.In(h.Location)
+	return nil
+}
+
+// StackTraceField is the key of the stack trace added by StackTraceHook
+const StackTraceField = "stacktrace"
+
+// o4gPackage is the function name prefix of frames inside this package
+const o4gPackage = "github.com/l00pss/helpme/o4g_logger."
+
+// StackTraceHook adds the stack of the logging goroutine to entries at or above Level
+type StackTraceHook struct {
+	Level logrus.Level
+}
+
+// NewStackTraceHook creates a new stack trace hook
+func NewStackTraceHook(level logrus.Level) *StackTraceHook {
+	return &StackTraceHook{
+		Level: level,
+	}
+}
+
+// Levels returns the levels this hook should be fired for
+func (h *StackTraceHook) Levels() []logrus.Level {
+	return logrus.AllLevels[:h.Level+1]
+}
+
+// Fire adds the stack as "function file:line" lines, starting at the frame
+// that called the logger and leaving out runtime frames
+func (h *StackTraceHook) Fire(entry *logrus.Entry) error {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+
+	var stack []string
+	inLogger := true
+	for {
+		frame, more := frames.Next()
+		if inLogger && !isLoggerFrame(frame) {
+			inLogger = false
+		}
+		if !inLogger && !strings.HasPrefix(frame.Function, "runtime.") {
+			stack = append(stack, fmt.Sprintf("%s %s:%d", frame.Function, frame.File, frame.Line))
+		}
+		if !more {
+			break
+		}
+	}
+
+	entry.Data[StackTraceField] = stack
+	return nil
+}
+
+// isLoggerFrame reports whether frame is inside logrus or this package's non-test code
+func isLoggerFrame(frame runtime.Frame) bool {
+	if strings.HasPrefix(frame.Function, logrusPackage) {
+		return true
+	}
+	return strings.HasPrefix(frame.Function, o4gPackage) && !strings.HasSuffix(frame.File, "_test.go")
+}
+
+// MetricsHook reports the level of every entry to a callback, e.g. to increment a counter
+type MetricsHook struct {
+	OnEntry func(level logrus.Level)
+}
+
+// NewMetricsHook creates a new metrics hook
+func NewMetricsHook(onEntry func(level logrus.Level)) *MetricsHook {
+	return &MetricsHook{
+		OnEntry: onEntry,
+	}
+}
+
+// Levels returns the levels this hook should be fired for
+func (h *MetricsHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire calls the callback with the entry level
+func (h *MetricsHook) Fire(entry *logrus.Entry) error {
+	h.OnEntry(entry.Level)
+	return nil
+}
+
+// LogKeyField is the field used by RateLimitHook to group entries instead of the message
+const LogKeyField = "log_key"
+
+// SuppressedField reports how many entries RateLimitHook dropped in the previous window
+const SuppressedField = "suppressed"
+
+// RateLimitHook allows at most one entry per key within an interval. It is
+// installed with EnableRateLimit, which drops suppressed entries from the
+// output and from the logger's other hooks.
+//
+// When a window in which entries were suppressed ends, one entry with the
+// level and message that opened the window is logged with the suppressed
+// count. Windows without suppressed entries are evicted at most once per
+// interval.
+type RateLimitHook struct {
+	Interval time.Duration
+
+	logger    *logrus.Logger
+	mu        sync.Mutex
+	windows   map[string]*rateLimitWindow
+	pending   map[*logrus.Entry]time.Time
+	lastSweep time.Time
+}
+
+// rateLimitWindow tracks the current window for one key
+type rateLimitWindow struct {
+	start      time.Time
+	suppressed int
+
+	// The entry that opened the window, repeated when the count is reported
+	level   logrus.Level
+	message string
+	logKey  interface{}
+}
+
+// newRateLimitHook creates a new rate limit hook reporting suppressed counts to logger
+func newRateLimitHook(logger *logrus.Logger, interval time.Duration) *RateLimitHook {
+	return &RateLimitHook{
+		Interval: interval,
+		logger:   logger,
+		windows:  make(map[string]*rateLimitWindow),
+		pending:  make(map[*logrus.Entry]time.Time),
+	}
+}
+
+// allow reports whether entry may be logged. Suppressed entries are
+// remembered until the formatter drops them.
+func (h *RateLimitHook) allow(entry *logrus.Entry) bool {
+	key := entry.Message
+	logKey, hasLogKey := entry.Data[LogKeyField]
+	if hasLogKey {
+		key = fmt.Sprint(logKey)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := entry.Time
+	if now.IsZero() {
+		now = time.Now()
+	}
+	defer h.sweep(now)
+
+	window, exists := h.windows[key]
+	if exists && now.Sub(window.start) < h.Interval {
+		if window.suppressed == 0 {
+			time.AfterFunc(window.start.Add(h.Interval).Sub(now), func() {
+				h.report(key, window)
+			})
+		}
+		window.suppressed++
+		h.pending[entry] = now
+		return false
+	}
+
+	h.windows[key] = &rateLimitWindow{start: now, level: entry.Level, message: entry.Message, logKey: logKey}
+	return true
+}
+
+// report ends window and logs how many entries it suppressed
+func (h *RateLimitHook) report(key string, window *rateLimitWindow) {
+	h.mu.Lock()
+	if h.windows[key] == window {
+		delete(h.windows, key)
+	}
+	suppressed := window.suppressed
+	h.mu.Unlock()
+
+	fields := logrus.Fields{SuppressedField: suppressed}
+	if window.logKey != nil {
+		fields[LogKeyField] = window.logKey
+	}
+	// Logging at panic level would panic in the timer goroutine
+	level := max(window.level, logrus.FatalLevel)
+	h.logger.WithFields(fields).Log(level, window.message)
+}
+
+// sweep evicts expired windows without suppressed entries, whose count is
+// reported by report, and suppressed entries that were never formatted, at
+// most once per interval. The caller must hold h.mu.
+func (h *RateLimitHook) sweep(now time.Time) {
+	if now.Sub(h.lastSweep) < h.Interval {
+		return
+	}
+	h.lastSweep = now
+
+	for key, window := range h.windows {
+		if window.suppressed == 0 && now.Sub(window.start) >= h.Interval {
+			delete(h.windows, key)
+		}
+	}
+	for entry, suppressedAt := range h.pending {
+		if now.Sub(suppressedAt) >= h.Interval {
+			delete(h.pending, entry)
+		}
+	}
+}
+
+// suppressed reports whether entry was suppressed and forgets it
+func (h *RateLimitHook) suppressed(entry *logrus.Entry) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	_, ok := h.pending[entry]
+	delete(h.pending, entry)
+	return ok
+}
+
+// formatter wraps next so that entries suppressed by the hook produce no output
+func (h *RateLimitHook) formatter(next logrus.Formatter) logrus.Formatter {
+	return &rateLimitFormatter{hook: h, next: next}
+}
+
+// rateLimitFormatter drops entries suppressed by a RateLimitHook
+type rateLimitFormatter struct {
+	hook *RateLimitHook
+	next logrus.Formatter
+}
+
+// Format returns nothing for suppressed entries and delegates otherwise
+func (f *rateLimitFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	if f.hook.suppressed(entry) {
+		return nil, nil
+	}
+	return f.next.Format(entry)
+}
+
+// rateLimitedHooks fires the wrapped hooks only for entries its RateLimitHook allows
+type rateLimitedHooks struct {
+	limiter *RateLimitHook
+
+	mu    sync.RWMutex
+	hooks logrus.LevelHooks
+}
+
+// Levels returns the levels this hook should be fired for
+func (h *rateLimitedHooks) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire fires the wrapped hooks unless the entry is suppressed
+func (h *rateLimitedHooks) Fire(entry *logrus.Entry) error {
+	if !h.limiter.allow(entry) {
+		return nil
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.hooks.Fire(entry.Level, entry)
+}
+
+// add adds hook to the wrapped hooks
+func (h *rateLimitedHooks) add(hook logrus.Hook) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.hooks.Add(hook)
+}
+
+// clone returns a copy sharing the limiter but not the wrapped hooks, which
+// are copied with cloneHooks
+func (h *rateLimitedHooks) clone(swap map[logrus.Hook]logrus.Hook) *rateLimitedHooks {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return &rateLimitedHooks{limiter: h.limiter, hooks: cloneHooks(h.hooks, swap)}
+}
+
+// rateLimited returns the hooks wrapper installed by EnableRateLimit, if any
+func (l *Logger) rateLimited() *rateLimitedHooks {
+	for _, hooks := range l.Logger.Hooks {
+		for _, hook := range hooks {
+			if limited, ok := hook.(*rateLimitedHooks); ok {
+				return limited
+			}
+		}
+	}
+	return nil
+}
+
+// EnableRateLimit installs a RateLimitHook and its formatter on the logger,
+// logging at most one entry per message, or per log_key field, within interval.
+// The logger's hooks, including hooks added later with AddHook, are only
+// fired for entries that are not suppressed.
+func (l *Logger) EnableRateLimit(interval time.Duration) *RateLimitHook {
+	hook := newRateLimitHook(l.Logger, interval)
+	limited := &rateLimitedHooks{limiter: hook, hooks: l.Logger.ReplaceHooks(make(logrus.LevelHooks))}
+	l.Logger.AddHook(limited)
+	l.Logger.SetFormatter(hook.formatter(l.Logger.Formatter))
+	return hook
+}
+
+// AddHook adds a hook to the logger, behind the rate limit if one is enabled
 func (l *Logger) AddHook(hook logrus.Hook) {
+	if limited := l.rateLimited(); limited != nil {
+		limited.add(hook)
+		return
+	}
 	l.Logger.AddHook(hook)
 }
 
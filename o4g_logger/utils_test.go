@@ -3,11 +3,18 @@ package o4g_logger
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"io"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/l00pss/helpme/goerr"
+	"github.com/l00pss/helpme/o4g_logger/o4g_loggertest"
 	"github.com/sirupsen/logrus"
 )
 
@@ -146,6 +153,20 @@ func TestWithUserID(t *testing.T) {
 	}
 }
 
+func TestContextIDsCapturedByGoErr(t *testing.T) {
+	if err := Init(DefaultConfig()); err != nil {
+		t.Fatalf("Failed to initialize logger: %v", err)
+	}
+
+	_, ctx := WithRequestID(context.Background(), "req-12345")
+	_, ctx = WithUserID(ctx, "user-67890")
+
+	values := goerr.WrapWithContext(ctx, errors.New("failed")).Context()
+	if values["request_id"] != "req-12345" || values["user_id"] != "user-67890" {
+		t.Errorf("Expected goerr to capture the logger's context IDs, got %v", values)
+	}
+}
+
 func TestContextKeys(t *testing.T) {
 	// Test that context keys are properly defined
 	tests := []struct {
@@ -211,6 +232,49 @@ func TestContextChaining(t *testing.T) {
 	}
 }
 
+func TestRegisterContextFields(t *testing.T) {
+	contextFieldsMu.RLock()
+	saved := slices.Clone(contextFields)
+	contextFieldsMu.RUnlock()
+	t.Cleanup(func() {
+		contextFieldsMu.Lock()
+		contextFields = saved
+		contextFieldsMu.Unlock()
+	})
+
+	var buf bytes.Buffer
+	config := DefaultConfig()
+	config.Format = JSONFormat
+	logger, err := NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.SetOutput(&buf)
+
+	const tenantKey ContextKey = "tenant_id"
+	RegisterContextFields(tenantKey, tenantKey)
+
+	ctx := ToContext(context.Background(), logger)
+	ctx = context.WithValue(ctx, tenantKey, "acme")
+	_, ctx = WithRequestID(ctx, "req-123")
+
+	CtxLog(ctx).Info("Tenant request")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if entry["tenant_id"] != "acme" {
+		t.Errorf("Expected registered tenant_id field, got: %s", buf.String())
+	}
+	if entry["request_id"] != "req-123" {
+		t.Errorf("Expected request_id field, got: %s", buf.String())
+	}
+	if _, ok := entry["user_id"]; ok {
+		t.Errorf("Expected unset user_id to be omitted, got: %s", buf.String())
+	}
+}
+
 func TestFromContextWithWrongType(t *testing.T) {
 	// Add a non-logger value with the logger key
 	ctx := context.WithValue(context.Background(), LoggerContextKey, "not a logger")
@@ -285,6 +349,9 @@ func TestTimerStop(t *testing.T) {
 	if !strings.Contains(output, "duration_ms") {
 		t.Error("Timer output should contain duration_ms field")
 	}
+	if !strings.Contains(output, "duration_human") {
+		t.Error("Timer output should contain duration_human field")
+	}
 }
 
 func TestTimerStopf(t *testing.T) {
@@ -367,6 +434,248 @@ func TestNewHook(t *testing.T) {
 	}
 }
 
+func TestHookUsesLoggerFormatter(t *testing.T) {
+	var out, hookOut bytes.Buffer
+
+	config := DefaultConfig()
+	config.Format = JSONFormat
+	logger, err := NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.SetOutput(&out)
+	logger.AddHook(NewHook(&hookOut, logrus.InfoLevel))
+
+	logger.WithField("order_id", 7).Info("Order placed")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(hookOut.Bytes(), &entry); err != nil {
+		t.Fatalf("Expected hook to write JSON, got %q: %v", hookOut.String(), err)
+	}
+	if entry["message"] != "Order placed" || entry["order_id"] != float64(7) {
+		t.Errorf("Unexpected hook entry: %s", hookOut.String())
+	}
+	if hookOut.String() != out.String() {
+		t.Errorf("Expected hook output to match logger output:\n%s\n%s", hookOut.String(), out.String())
+	}
+}
+
+func TestHookFormatterOverride(t *testing.T) {
+	var hookOut bytes.Buffer
+
+	config := DefaultConfig()
+	config.Format = JSONFormat
+	logger, err := NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.SetOutput(io.Discard)
+
+	hook := NewHook(&hookOut, logrus.InfoLevel)
+	hook.Formatter = &logrus.TextFormatter{DisableTimestamp: true}
+	logger.AddHook(hook)
+
+	logger.Info("Plain text")
+
+	if !strings.Contains(hookOut.String(), `msg="Plain text"`) {
+		t.Errorf("Expected hook to use its own formatter, got %q", hookOut.String())
+	}
+}
+
+func TestMetricsHook(t *testing.T) {
+	counts := make(map[logrus.Level]int)
+
+	logger, err := NewLogger(DefaultConfig())
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.SetOutput(io.Discard)
+	logger.AddHook(NewMetricsHook(func(level logrus.Level) {
+		counts[level]++
+	}))
+
+	logger.Info("first")
+	logger.Info("second")
+	logger.Error("failure")
+	logger.Debug("filtered by level")
+
+	if counts[logrus.InfoLevel] != 2 {
+		t.Errorf("Expected 2 info entries, got %d", counts[logrus.InfoLevel])
+	}
+	if counts[logrus.ErrorLevel] != 1 {
+		t.Errorf("Expected 1 error entry, got %d", counts[logrus.ErrorLevel])
+	}
+	if counts[logrus.DebugLevel] != 0 {
+		t.Errorf("Expected disabled debug entries not to be counted, got %d", counts[logrus.DebugLevel])
+	}
+}
+
+func TestStackTraceHook(t *testing.T) {
+	var buf bytes.Buffer
+
+	config := DefaultConfig()
+	config.Format = JSONFormat
+	config.StackTraceLevel = ErrorLevel
+
+	logger, err := NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.SetOutput(&buf)
+
+	logger.Error("something failed")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	stack, ok := entry[StackTraceField].([]interface{})
+	if !ok || len(stack) == 0 {
+		t.Fatalf("Expected stack trace on error entry, got: %s", buf.String())
+	}
+	if first, _ := stack[0].(string); !strings.Contains(first, "TestStackTraceHook") {
+		t.Errorf("Expected stack to start at the test, got %v", stack[0])
+	}
+	for _, frame := range stack {
+		if line, _ := frame.(string); strings.Contains(line, "sirupsen/logrus") {
+			t.Errorf("Expected logrus frames to be trimmed, got %s", line)
+		}
+	}
+
+	buf.Reset()
+	logger.Info("all good")
+	if strings.Contains(buf.String(), StackTraceField) {
+		t.Errorf("Expected no stack trace on info entry, got: %s", buf.String())
+	}
+}
+
+func TestStackTraceLevelInvalid(t *testing.T) {
+	config := DefaultConfig()
+	config.StackTraceLevel = "loud"
+
+	if _, err := NewLogger(config); err == nil {
+		t.Error("Expected error for invalid stack trace level")
+	}
+}
+
+func TestNewLoggerInvalidConfigOpensNoOutput(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "app.log")
+
+	for _, config := range []Config{
+		{Level: InfoLevel, Output: output, StackTraceLevel: "loud"},
+		{Level: InfoLevel, Output: output, TimeZone: "Nowhere/City"},
+	} {
+		if _, err := NewLogger(config); err == nil {
+			t.Errorf("Expected error for invalid config %+v", config)
+		}
+		if _, err := os.Stat(output); !os.IsNotExist(err) {
+			t.Errorf("Expected the output file not to be opened, got %v", err)
+		}
+	}
+}
+
+func TestRateLimitHook(t *testing.T) {
+	var buf bytes.Buffer
+
+	config := DefaultConfig()
+	config.Format = JSONFormat
+	logger, err := NewLogger(config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.SetOutput(&buf)
+	logger.EnableRateLimit(time.Hour)
+
+	for i := 0; i < 100; i++ {
+		logger.Error("database unavailable")
+	}
+	logger.Error("different message")
+
+	lines := strings.Count(buf.String(), "\n")
+	if lines != 2 {
+		t.Errorf("Expected 2 lines to be written, got %d: %s", lines, buf.String())
+	}
+}
+
+func TestRateLimitHookLogKeyAndSuppressedCount(t *testing.T) {
+	logger, hook := newMemoryLogger(t)
+	logger.EnableRateLimit(50 * time.Millisecond)
+
+	for i := 0; i < 10; i++ {
+		logger.WithField(LogKeyField, "db").Errorf("query %d failed", i)
+	}
+	if count := hook.Count(logrus.ErrorLevel); count != 1 {
+		t.Fatalf("Expected entries sharing a log_key to be limited, got %d entries", count)
+	}
+
+	// The count is reported when the window ends, without another entry for the key
+	deadline := time.Now().Add(time.Second)
+	for hook.Count(logrus.ErrorLevel) < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	entries := hook.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("Expected the suppressed count to be reported after the window, got %d entries", len(entries))
+	}
+	report := entries[1]
+	if report.Data[SuppressedField] != 9 || report.Data[LogKeyField] != "db" || report.Message != "query 0 failed" {
+		t.Errorf("Unexpected suppressed count entry: %s %v", report.Message, report.Data)
+	}
+}
+
+func TestRateLimitHookGatesOtherHooks(t *testing.T) {
+	logger, before := newMemoryLogger(t)
+	logger.EnableRateLimit(time.Hour)
+	after := o4g_loggertest.NewMemoryHook()
+	logger.AddHook(after)
+
+	for i := 0; i < 100; i++ {
+		logger.Error("database unavailable")
+	}
+
+	if before.Count(logrus.ErrorLevel) != 1 || after.Count(logrus.ErrorLevel) != 1 {
+		t.Errorf("Expected hooks to see only the allowed entry, got %d and %d",
+			before.Count(logrus.ErrorLevel), after.Count(logrus.ErrorLevel))
+	}
+	for _, entry := range after.Entries() {
+		if len(entry.Data) != 0 {
+			t.Errorf("Expected no internal fields in entry data, got %v", entry.Data)
+		}
+	}
+}
+
+func TestRateLimitHookEvictsExpiredWindows(t *testing.T) {
+	logger, _ := newMemoryLogger(t)
+	hook := logger.EnableRateLimit(10 * time.Millisecond)
+
+	for i := 0; i < 1000; i++ {
+		logger.Errorf("distinct message %d", i)
+	}
+	time.Sleep(20 * time.Millisecond)
+	logger.Error("after the window")
+
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+	if len(hook.windows) != 1 || len(hook.pending) != 0 {
+		t.Errorf("Expected expired windows to be evicted, got %d windows and %d pending entries",
+			len(hook.windows), len(hook.pending))
+	}
+}
+
+// newMemoryLogger creates a logger discarding its output, recording entries in a memory hook
+func newMemoryLogger(t *testing.T) (*Logger, *o4g_loggertest.MemoryHook) {
+	logger, err := NewLogger(DefaultConfig())
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.SetOutput(io.Discard)
+
+	hook := o4g_loggertest.NewMemoryHook()
+	logger.AddHook(hook)
+	return logger, hook
+}
+
 // Benchmark tests
 func BenchmarkFromContext(b *testing.B) {
 	ctx := context.Background()
@@ -1,6 +1,14 @@
 package option
 
-import "fmt"
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrNone is returned by OkOrErr when the Option is None
+var ErrNone = errors.New("option: value is None")
 
 type Option[T any] struct {
 	value *T
@@ -36,6 +44,32 @@ func (o Option[T]) Expect(msg string) T {
 	return *o.value
 }
 
+// Expectf is like Expect but formats the panic message with fmt.Sprintf
+func (o Option[T]) Expectf(format string, args ...any) T {
+	if o.IsNone() {
+		panic(fmt.Sprintf(format, args...))
+	}
+	return *o.value
+}
+
+// Get returns the contained value and true, or the zero value and false if the Option is None
+func (o Option[T]) Get() (T, bool) {
+	if o.IsNone() {
+		var zero T
+		return zero, false
+	}
+	return *o.value, true
+}
+
+// OkOrErr returns the contained value and nil, or the zero value and ErrNone if the Option is None
+func (o Option[T]) OkOrErr() (T, error) {
+	if o.IsNone() {
+		var zero T
+		return zero, ErrNone
+	}
+	return *o.value, nil
+}
+
 func (o Option[T]) GetOrElse(defaultValue T) T {
 	if o.IsSome() {
 		return *o.value
@@ -50,6 +84,26 @@ func (o Option[T]) GetOrElseFunc(defaultFunc func() T) T {
 	return defaultFunc()
 }
 
+// GetOrInsert stores value in the Option if it is None and returns the
+// contained value. It mutates the receiver.
+func (o *Option[T]) GetOrInsert(value T) T {
+	if o.IsNone() {
+		o.value = &value
+	}
+	return *o.value
+}
+
+// GetOrInsertWith stores the result of f in the Option if it is None and
+// returns the contained value. f is only called when the Option is None.
+// It mutates the receiver.
+func (o *Option[T]) GetOrInsertWith(f func() T) T {
+	if o.IsNone() {
+		value := f()
+		o.value = &value
+	}
+	return *o.value
+}
+
 func (o Option[T]) Map(f func(T) interface{}) Option[interface{}] {
 	if o.IsSome() {
 		return Some(f(*o.value))
@@ -71,6 +125,14 @@ func (o Option[T]) Or(other Option[T]) Option[T] {
 	return other
 }
 
+// OrElse returns o if it is Some, otherwise the result of calling f
+func (o Option[T]) OrElse(f func() Option[T]) Option[T] {
+	if o.IsSome() {
+		return o
+	}
+	return f()
+}
+
 func (o Option[T]) And(other Option[T]) Option[T] {
 	if o.IsNone() {
 		return o
@@ -78,6 +140,14 @@ func (o Option[T]) And(other Option[T]) Option[T] {
 	return other
 }
 
+// Inspect calls f with the value if the Option is Some and returns the Option unchanged
+func (o Option[T]) Inspect(f func(T)) Option[T] {
+	if o.IsSome() {
+		f(*o.value)
+	}
+	return o
+}
+
 func (o Option[T]) Filter(predicate func(T) bool) Option[T] {
 	if o.IsSome() && predicate(*o.value) {
 		return o
@@ -114,6 +184,28 @@ func (o Option[T]) String() string {
 	return "None"
 }
 
+// MarshalJSON encodes Some as its value and None as null
+func (o Option[T]) MarshalJSON() ([]byte, error) {
+	if o.IsNone() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(*o.value)
+}
+
+// UnmarshalJSON decodes null as None and any other value as Some
+func (o *Option[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*o = None[T]()
+		return nil
+	}
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*o = Some(value)
+	return nil
+}
+
 func (o Option[T]) Flatten() Option[T] {
 	if o.IsSome() {
 		return o
@@ -148,3 +240,31 @@ func AndThen[T, U any](o Option[T], f func(T) Option[U]) Option[U] {
 	}
 	return None[U]()
 }
+
+func Map2[A, B, C any](a Option[A], b Option[B], f func(A, B) C) Option[C] {
+	if a.IsSome() && b.IsSome() {
+		return Some(f(*a.value, *b.value))
+	}
+	return None[C]()
+}
+
+// Flatten returns the values of the Some options, skipping None
+func Flatten[T any](opts []Option[T]) []T {
+	values := make([]T, 0, len(opts))
+	for _, o := range opts {
+		if o.IsSome() {
+			values = append(values, *o.value)
+		}
+	}
+	return values
+}
+
+// FirstSome returns the first Some option, or None if there is none
+func FirstSome[T any](opts ...Option[T]) Option[T] {
+	for _, o := range opts {
+		if o.IsSome() {
+			return o
+		}
+	}
+	return None[T]()
+}
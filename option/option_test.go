@@ -1,8 +1,11 @@
 package option
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -54,6 +57,55 @@ func TestExpectPanic(t *testing.T) {
 	None[string]().Expect("custom panic message")
 }
 
+func TestExpectf(t *testing.T) {
+	if Some(7).Expectf("missing %s", "value") != 7 {
+		t.Error("Some.Expectf should return value")
+	}
+
+	defer func() {
+		r := recover()
+		msg, ok := r.(string)
+		if !ok || !strings.Contains(msg, "user 42 not found") {
+			t.Errorf("None.Expectf() should panic with formatted message, got %v", r)
+		}
+	}()
+	None[string]().Expectf("user %d not found", 42)
+}
+
+func TestGet(t *testing.T) {
+	value, ok := Some(42).Get()
+	if !ok || value != 42 {
+		t.Errorf("Some(42).Get() = (%v, %v), want (42, true)", value, ok)
+	}
+
+	zero, ok := Some(0).Get()
+	if !ok || zero != 0 {
+		t.Errorf("Some(0).Get() = (%v, %v), want (0, true)", zero, ok)
+	}
+
+	noneValue, ok := None[string]().Get()
+	if ok || noneValue != "" {
+		t.Errorf("None.Get() = (%q, %v), want (\"\", false)", noneValue, ok)
+	}
+}
+
+func TestOkOrErr(t *testing.T) {
+	value, err := Some(42).OkOrErr()
+	if err != nil || value != 42 {
+		t.Errorf("Some(42).OkOrErr() = (%v, %v), want (42, nil)", value, err)
+	}
+
+	noneValue, err := None[string]().OkOrErr()
+	if noneValue != "" || !errors.Is(err, ErrNone) {
+		t.Errorf("None.OkOrErr() = (%q, %v), want (\"\", ErrNone)", noneValue, err)
+	}
+
+	wrapped := fmt.Errorf("load user: %w", err)
+	if !errors.Is(wrapped, ErrNone) {
+		t.Error("errors.Is should find ErrNone through wrapping")
+	}
+}
+
 func TestGetOrElse(t *testing.T) {
 	someOpt := Some(42)
 	if someOpt.GetOrElse(0) != 42 {
@@ -78,6 +130,42 @@ func TestGetOrElseFunc(t *testing.T) {
 	}
 }
 
+func TestGetOrInsert(t *testing.T) {
+	opt := None[int]()
+	if opt.GetOrInsert(42) != 42 {
+		t.Error("None.GetOrInsert should return inserted value")
+	}
+	if !opt.IsSome() || opt.Unwrap() != 42 {
+		t.Error("None.GetOrInsert should make the Option Some")
+	}
+
+	if opt.GetOrInsert(99) != 42 {
+		t.Error("Some.GetOrInsert should keep the existing value")
+	}
+}
+
+func TestGetOrInsertWith(t *testing.T) {
+	opt := None[string]()
+	if opt.GetOrInsertWith(func() string { return "hello" }) != "hello" {
+		t.Error("None.GetOrInsertWith should return function result")
+	}
+	if !opt.IsSome() || opt.Unwrap() != "hello" {
+		t.Error("None.GetOrInsertWith should make the Option Some")
+	}
+
+	called := false
+	got := opt.GetOrInsertWith(func() string {
+		called = true
+		return "world"
+	})
+	if got != "hello" {
+		t.Errorf("Some.GetOrInsertWith = %v, want hello", got)
+	}
+	if called {
+		t.Error("Some.GetOrInsertWith should not call the function")
+	}
+}
+
 func TestMap(t *testing.T) {
 	someOpt := Some(42)
 	mapped := someOpt.Map(func(x int) interface{} { return x * 2 })
@@ -112,6 +200,32 @@ func TestGenericMap(t *testing.T) {
 	}
 }
 
+func TestMap2(t *testing.T) {
+	add := func(a, b int) int { return a + b }
+
+	sum := Map2(Some(2), Some(3), add)
+	if !sum.IsSome() || sum.Unwrap() != 5 {
+		t.Errorf("Map2 on Some/Some = %v, want Some(5)", sum)
+	}
+
+	if !Map2(None[int](), Some(3), add).IsNone() {
+		t.Error("Map2 on None/Some should return None")
+	}
+	if !Map2(Some(2), None[int](), add).IsNone() {
+		t.Error("Map2 on Some/None should return None")
+	}
+	if !Map2(None[int](), None[int](), add).IsNone() {
+		t.Error("Map2 on None/None should return None")
+	}
+
+	label := Map2(Some("age"), Some(42), func(k string, v int) string {
+		return k + "=" + strconv.Itoa(v)
+	})
+	if label.Unwrap() != "age=42" {
+		t.Errorf("Map2 with mixed types = %v, want age=42", label.Unwrap())
+	}
+}
+
 func TestAndThen(t *testing.T) {
 	someOpt := Some(42)
 	result := someOpt.AndThen(func(x int) Option[interface{}] {
@@ -167,6 +281,22 @@ func TestOr(t *testing.T) {
 	}
 }
 
+func TestOrElse(t *testing.T) {
+	called := false
+	fallback := func() Option[int] {
+		called = true
+		return Some(99)
+	}
+
+	if Some(42).OrElse(fallback).Unwrap() != 42 || called {
+		t.Error("Some.OrElse should return the value without calling f")
+	}
+
+	if None[int]().OrElse(fallback).Unwrap() != 99 || !called {
+		t.Error("None.OrElse should call f and return its result")
+	}
+}
+
 func TestAnd(t *testing.T) {
 	someOpt := Some(42)
 	other := Some(99)
@@ -262,6 +392,80 @@ func TestToSlice(t *testing.T) {
 	}
 }
 
+func TestJSONRoundTrip(t *testing.T) {
+	data, err := json.Marshal(Some(42))
+	if err != nil || string(data) != "42" {
+		t.Errorf("json.Marshal(Some(42)) = (%s, %v), want 42", data, err)
+	}
+	data, err = json.Marshal(None[int]())
+	if err != nil || string(data) != "null" {
+		t.Errorf("json.Marshal(None) = (%s, %v), want null", data, err)
+	}
+
+	var some Option[int]
+	if err := json.Unmarshal([]byte("42"), &some); err != nil || some.Unwrap() != 42 {
+		t.Errorf("json.Unmarshal(42) = (%v, %v), want Some(42)", some, err)
+	}
+	none := Some(1)
+	if err := json.Unmarshal([]byte("null"), &none); err != nil || none.IsSome() {
+		t.Errorf("json.Unmarshal(null) = (%v, %v), want None", none, err)
+	}
+	var invalid Option[int]
+	if err := json.Unmarshal([]byte(`"abc"`), &invalid); err == nil {
+		t.Error("json.Unmarshal of a string into Option[int] should fail")
+	}
+}
+
+func TestJSONStructField(t *testing.T) {
+	type response struct {
+		ID       int            `json:"id"`
+		Nickname Option[string] `json:"nickname"`
+	}
+
+	data, err := json.Marshal(response{ID: 1, Nickname: Some("neo")})
+	if err != nil || string(data) != `{"id":1,"nickname":"neo"}` {
+		t.Errorf("json.Marshal(Some field) = (%s, %v)", data, err)
+	}
+	data, err = json.Marshal(response{ID: 2})
+	if err != nil || string(data) != `{"id":2,"nickname":null}` {
+		t.Errorf("json.Marshal(None field) = (%s, %v)", data, err)
+	}
+
+	var decoded response
+	if err := json.Unmarshal([]byte(`{"id":3,"nickname":"trinity"}`), &decoded); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	if decoded.Nickname.Unwrap() != "trinity" {
+		t.Errorf("Nickname = %v, want Some(trinity)", decoded.Nickname)
+	}
+
+	decoded = response{}
+	if err := json.Unmarshal([]byte(`{"id":4}`), &decoded); err != nil || decoded.Nickname.IsSome() {
+		t.Errorf("missing field = (%v, %v), want None", decoded.Nickname, err)
+	}
+}
+
+func TestInspect(t *testing.T) {
+	var seen []int
+	record := func(v int) { seen = append(seen, v) }
+
+	result := Some(42).Inspect(record)
+	if result.Unwrap() != 42 {
+		t.Errorf("Some(42).Inspect() = %v, want Some(42)", result)
+	}
+	if len(seen) != 1 || seen[0] != 42 {
+		t.Errorf("Inspect callback calls = %v, want [42]", seen)
+	}
+
+	seen = nil
+	if None[int]().Inspect(record).IsSome() {
+		t.Error("None.Inspect() should return None")
+	}
+	if len(seen) != 0 {
+		t.Errorf("Inspect callback should not fire on None, got %v", seen)
+	}
+}
+
 func TestString(t *testing.T) {
 	someOpt := Some(42)
 	str := someOpt.String()
@@ -327,6 +531,31 @@ func TestChaining(t *testing.T) {
 	}
 }
 
+func TestFlatten(t *testing.T) {
+	values := Flatten([]Option[int]{Some(1), None[int](), Some(3), None[int]()})
+	if len(values) != 2 || values[0] != 1 || values[1] != 3 {
+		t.Errorf("Flatten() = %v, want [1 3]", values)
+	}
+
+	if empty := Flatten([]Option[int]{None[int]()}); len(empty) != 0 {
+		t.Errorf("Flatten() of only None = %v, want []", empty)
+	}
+}
+
+func TestFirstSome(t *testing.T) {
+	first := FirstSome(None[string](), Some("env"), Some("file"))
+	if first.Unwrap() != "env" {
+		t.Errorf("FirstSome() = %v, want Some(env)", first)
+	}
+
+	if FirstSome(None[string](), None[string]()).IsSome() {
+		t.Error("FirstSome() of only None should be None")
+	}
+	if FirstSome[string]().IsSome() {
+		t.Error("FirstSome() without arguments should be None")
+	}
+}
+
 func BenchmarkSomeCreation(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_ = Some(i)
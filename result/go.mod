@@ -1,3 +1,5 @@
 module github.com/l00pss/helpme/result
 
 go 1.25
+
+require github.com/l00pss/helpme/option v0.1.0
@@ -1,5 +1,17 @@
 package result
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/l00pss/helpme/option"
+)
+
+// ErrMissingOption is returned by ZipWithOption when the Option is None
+var ErrMissingOption = errors.New("result: option is None")
+
 type Result[T any] struct {
 	value T
 	err   error
@@ -50,6 +62,37 @@ func (r Result[T]) ExpectErr(msg string) error {
 	return r.err
 }
 
+// Expectf is like Expect but formats the panic message with fmt.Sprintf
+func (r Result[T]) Expectf(format string, args ...any) T {
+	if r.IsErr() {
+		panic(fmt.Sprintf(format, args...))
+	}
+	return r.value
+}
+
+// ExpectErrf is like ExpectErr but formats the panic message with fmt.Sprintf
+func (r Result[T]) ExpectErrf(format string, args ...any) error {
+	if r.IsOk() {
+		panic(fmt.Sprintf(format, args...))
+	}
+	return r.err
+}
+
+// Get returns the value and error as a Go (T, error) pair.
+// The zero value of T is returned when the Result is Err.
+func (r Result[T]) Get() (T, error) {
+	if r.IsErr() {
+		var zero T
+		return zero, r.err
+	}
+	return r.value, nil
+}
+
+// AsError returns the error if the Result is Err, or nil if it is Ok
+func (r Result[T]) AsError() error {
+	return r.err
+}
+
 func (r Result[T]) GetOrElse(defaultValue T) T {
 	if r.IsOk() {
 		return r.value
@@ -92,6 +135,14 @@ func (r Result[T]) Or(other Result[T]) Result[T] {
 	return other
 }
 
+// OrElse returns r if it is Ok, otherwise the result of calling f with the error
+func (r Result[T]) OrElse(f func(error) Result[T]) Result[T] {
+	if r.IsOk() {
+		return r
+	}
+	return f(r.err)
+}
+
 func (r Result[T]) And(other Result[T]) Result[T] {
 	if r.IsErr() {
 		return r
@@ -106,6 +157,16 @@ func (r Result[T]) Filter(predicate func(T) bool, err error) Result[T] {
 	return r
 }
 
+// Contains reports whether r is Ok with a value equal to value according to eq
+func (r Result[T]) Contains(value T, eq func(T, T) bool) bool {
+	return r.IsOk() && eq(r.value, value)
+}
+
+// Exists reports whether r is Ok with a value satisfying predicate
+func (r Result[T]) Exists(predicate func(T) bool) bool {
+	return r.IsOk() && predicate(r.value)
+}
+
 func Map[T, U any](r Result[T], f func(T) U) Result[U] {
 	if r.IsOk() {
 		return Ok(f(r.value))
@@ -113,9 +174,185 @@ func Map[T, U any](r Result[T], f func(T) U) Result[U] {
 	return Err[U](r.err)
 }
 
+// MapOr returns f applied to the Ok value, or def if r is Err
+func MapOr[T, U any](r Result[T], def U, f func(T) U) U {
+	if r.IsOk() {
+		return f(r.value)
+	}
+	return def
+}
+
+// MapOrElse returns f applied to the Ok value, or onErr applied to the error if r is Err
+func MapOrElse[T, U any](r Result[T], onErr func(error) U, f func(T) U) U {
+	if r.IsOk() {
+		return f(r.value)
+	}
+	return onErr(r.err)
+}
+
+func MapErr[T any](r Result[T], f func(error) error) Result[T] {
+	if r.IsErr() {
+		return Err[T](f(r.err))
+	}
+	return r
+}
+
 func AndThen[T, U any](r Result[T], f func(T) Result[U]) Result[U] {
 	if r.IsOk() {
 		return f(r.value)
 	}
 	return Err[U](r.err)
 }
+
+// Ap applies the function in rf to the value in rv, returning rf's error first, then rv's
+func Ap[T, U any](rf Result[func(T) U], rv Result[T]) Result[U] {
+	if rf.IsErr() {
+		return Err[U](rf.err)
+	}
+	if rv.IsErr() {
+		return Err[U](rv.err)
+	}
+	return Ok(rf.value(rv.value))
+}
+
+// Flatten returns the inner result if r is Ok, or r's error otherwise
+func Flatten[T any](r Result[Result[T]]) Result[T] {
+	if r.IsOk() {
+		return r.value
+	}
+	return Err[T](r.err)
+}
+
+// AllOk reports whether every result is Ok. It is true for an empty slice.
+func AllOk[T any](results []Result[T]) bool {
+	for _, r := range results {
+		if r.IsErr() {
+			return false
+		}
+	}
+	return true
+}
+
+// AnyErr returns the first error found and whether there was one
+func AnyErr[T any](results []Result[T]) (error, bool) {
+	for _, r := range results {
+		if r.IsErr() {
+			return r.err, true
+		}
+	}
+	return nil, false
+}
+
+// Pair holds two values of possibly different types
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// ZipWithOption pairs the Ok value of r with the value of o. It returns r's
+// error if r is Err, or ErrMissingOption if o is None.
+func ZipWithOption[T, U any](r Result[T], o option.Option[U]) Result[Pair[T, U]] {
+	if r.IsErr() {
+		return Err[Pair[T, U]](r.err)
+	}
+	value, ok := o.Get()
+	if !ok {
+		return Err[Pair[T, U]](ErrMissingOption)
+	}
+	return Ok(Pair[T, U]{First: r.value, Second: value})
+}
+
+// Tuple3 holds three values of possibly different types
+type Tuple3[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+// Tuple4 holds four values of possibly different types
+type Tuple4[A, B, C, D any] struct {
+	First  A
+	Second B
+	Third  C
+	Fourth D
+}
+
+// Combine3 returns the values of three Ok results as a Tuple3, or the first Err
+func Combine3[A, B, C any](a Result[A], b Result[B], c Result[C]) Result[Tuple3[A, B, C]] {
+	if err, ok := firstErr(a.err, b.err, c.err); ok {
+		return Err[Tuple3[A, B, C]](err)
+	}
+	return Ok(Tuple3[A, B, C]{First: a.value, Second: b.value, Third: c.value})
+}
+
+// Combine4 returns the values of four Ok results as a Tuple4, or the first Err
+func Combine4[A, B, C, D any](a Result[A], b Result[B], c Result[C], d Result[D]) Result[Tuple4[A, B, C, D]] {
+	if err, ok := firstErr(a.err, b.err, c.err, d.err); ok {
+		return Err[Tuple4[A, B, C, D]](err)
+	}
+	return Ok(Tuple4[A, B, C, D]{First: a.value, Second: b.value, Third: c.value, Fourth: d.value})
+}
+
+func firstErr(errs ...error) (error, bool) {
+	for _, err := range errs {
+		if err != nil {
+			return err, true
+		}
+	}
+	return nil, false
+}
+
+// Await runs f in a goroutine and returns its Result, or an Err wrapping
+// ctx.Err() if the context is done first. Await cannot stop f, so f should
+// observe the same ctx to avoid outliving the caller; its result is dropped
+// once the context wins.
+func Await[T any](ctx context.Context, f func() Result[T]) Result[T] {
+	done := make(chan Result[T], 1)
+	go func() {
+		done <- f()
+	}()
+
+	select {
+	case r := <-done:
+		return r
+	case <-ctx.Done():
+		return Err[T](fmt.Errorf("await: %w", ctx.Err()))
+	}
+}
+
+// Catch calls f and returns its value as Ok, or an Err if f panics. A
+// recovered error is wrapped so errors.Is and errors.As still match it.
+func Catch[T any](f func() T) (r Result[T]) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			if err, ok := recovered.(error); ok {
+				r = Err[T](fmt.Errorf("panic: %w", err))
+			} else {
+				r = Err[T](fmt.Errorf("panic: %v", recovered))
+			}
+		}
+	}()
+	return Ok(f())
+}
+
+// Retry calls f until it returns Ok or attempts run out, sleeping backoff
+// between tries. f is always called at least once. The last Err is returned
+// when every attempt fails.
+func Retry[T any](attempts int, backoff time.Duration, f func() Result[T]) Result[T] {
+	return retry(attempts, backoff, 1, f)
+}
+
+// RetryExponential is like Retry but doubles the backoff after each failed try
+func RetryExponential[T any](attempts int, backoff time.Duration, f func() Result[T]) Result[T] {
+	return retry(attempts, backoff, 2, f)
+}
+
+func retry[T any](attempts int, backoff time.Duration, factor int, f func() Result[T]) Result[T] {
+	r := f()
+	for i := 1; i < attempts && r.IsErr(); i++ {
+		time.Sleep(backoff)
+		backoff *= time.Duration(factor)
+		r = f()
+	}
+	return r
+}
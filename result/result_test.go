@@ -1,10 +1,15 @@
 package result
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/l00pss/helpme/option"
 )
 
 func TestOk(t *testing.T) {
@@ -85,6 +90,67 @@ func TestExpectErrPanic(t *testing.T) {
 	Ok(42).ExpectErr("custom panic message")
 }
 
+func TestExpectf(t *testing.T) {
+	if Ok(42).Expectf("loading %s", "config") != 42 {
+		t.Error("Ok.Expectf should return value")
+	}
+
+	defer func() {
+		r := recover()
+		msg, ok := r.(string)
+		if !ok || !strings.Contains(msg, "loading config.yaml failed after 3 tries") {
+			t.Errorf("Err.Expectf() should panic with formatted message, got %v", r)
+		}
+	}()
+	Err[int](errors.New("test")).Expectf("loading %s failed after %d tries", "config.yaml", 3)
+}
+
+func TestExpectErrf(t *testing.T) {
+	err := errors.New("test error")
+	if Err[int](err).ExpectErrf("expected %s", "failure") != err {
+		t.Error("Err.ExpectErrf should return error")
+	}
+
+	defer func() {
+		r := recover()
+		msg, ok := r.(string)
+		if !ok || !strings.Contains(msg, "expected failure for id 7") {
+			t.Errorf("Ok.ExpectErrf() should panic with formatted message, got %v", r)
+		}
+	}()
+	Ok(42).ExpectErrf("expected failure for id %d", 7)
+}
+
+func TestGet(t *testing.T) {
+	value, err := Ok(42).Get()
+	if err != nil {
+		t.Errorf("Ok.Get() error = %v, want nil", err)
+	}
+	if value != 42 {
+		t.Errorf("Ok.Get() value = %v, want 42", value)
+	}
+
+	testErr := errors.New("test error")
+	errValue, err := Err[string](testErr).Get()
+	if err != testErr {
+		t.Errorf("Err.Get() error = %v, want %v", err, testErr)
+	}
+	if errValue != "" {
+		t.Errorf("Err.Get() value = %q, want zero value", errValue)
+	}
+}
+
+func TestAsError(t *testing.T) {
+	if err := Ok(42).AsError(); err != nil {
+		t.Errorf("Ok.AsError() = %v, want nil", err)
+	}
+
+	expected := errors.New("test error")
+	if err := Err[int](expected).AsError(); err != expected {
+		t.Errorf("Err.AsError() = %v, want %v", err, expected)
+	}
+}
+
 func TestGetOrElse(t *testing.T) {
 	okResult := Ok(42)
 	if okResult.GetOrElse(0) != 42 {
@@ -143,6 +209,29 @@ func TestGenericMap(t *testing.T) {
 	}
 }
 
+func TestMapOr(t *testing.T) {
+	length := func(s string) int { return len(s) }
+
+	if got := MapOr(Ok("hello"), -1, length); got != 5 {
+		t.Errorf("MapOr on Ok = %d, want 5", got)
+	}
+	if got := MapOr(Err[string](errors.New("test")), -1, length); got != -1 {
+		t.Errorf("MapOr on Err = %d, want -1", got)
+	}
+}
+
+func TestMapOrElse(t *testing.T) {
+	describe := func(n int) string { return "value " + strconv.Itoa(n) }
+	onErr := func(err error) string { return "error: " + err.Error() }
+
+	if got := MapOrElse(Ok(42), onErr, describe); got != "value 42" {
+		t.Errorf("MapOrElse on Ok = %q, want %q", got, "value 42")
+	}
+	if got := MapOrElse(Err[int](errors.New("boom")), onErr, describe); got != "error: boom" {
+		t.Errorf("MapOrElse on Err = %q, want %q", got, "error: boom")
+	}
+}
+
 func TestMapErr(t *testing.T) {
 	okResult := Ok(42)
 	mappedOk := okResult.MapErr(func(e error) error { return errors.New("new error") })
@@ -160,6 +249,30 @@ func TestMapErr(t *testing.T) {
 	}
 }
 
+func TestGenericMapErr(t *testing.T) {
+	called := false
+	okResult := MapErr(Ok(42), func(err error) error {
+		called = true
+		return err
+	})
+	if !okResult.IsOk() || okResult.Unwrap() != 42 {
+		t.Error("MapErr on Ok should pass the value through")
+	}
+	if called {
+		t.Error("MapErr on Ok should not call the function")
+	}
+
+	errResult := MapErr(Err[int](errors.New("original")), func(err error) error {
+		return fmt.Errorf("wrapped: %w", err)
+	})
+	if !errResult.IsErr() {
+		t.Error("MapErr on Err should remain Err")
+	}
+	if errResult.UnwrapErr().Error() != "wrapped: original" {
+		t.Errorf("MapErr error = %v, want wrapped: original", errResult.UnwrapErr())
+	}
+}
+
 func TestAndThen(t *testing.T) {
 	okResult := Ok(42)
 	result := okResult.AndThen(func(x int) Result[interface{}] {
@@ -215,6 +328,31 @@ func TestOr(t *testing.T) {
 	}
 }
 
+func TestOrElse(t *testing.T) {
+	called := false
+	fallback := func(err error) Result[int] {
+		called = true
+		return Ok(99)
+	}
+
+	if Ok(42).OrElse(fallback).Unwrap() != 42 || called {
+		t.Error("Ok.OrElse should return the Ok value without calling f")
+	}
+
+	original := errors.New("primary failed")
+	var received error
+	result := Err[int](original).OrElse(func(err error) Result[int] {
+		received = err
+		return Ok(99)
+	})
+	if received != original {
+		t.Errorf("Err.OrElse should pass the error to f, got %v", received)
+	}
+	if result.Unwrap() != 99 {
+		t.Error("Err.OrElse should return the fallback result")
+	}
+}
+
 func TestAnd(t *testing.T) {
 	okResult := Ok(42)
 	other := Ok(99)
@@ -293,6 +431,39 @@ func TestErrorChaining(t *testing.T) {
 	}
 }
 
+func TestContains(t *testing.T) {
+	eq := func(a, b int) bool { return a == b }
+
+	if !Ok(42).Contains(42, eq) {
+		t.Error("Ok(42).Contains(42) should be true")
+	}
+	if Ok(42).Contains(7, eq) {
+		t.Error("Ok(42).Contains(7) should be false")
+	}
+	if Err[int](errors.New("failed")).Contains(0, eq) {
+		t.Error("Err.Contains should be false")
+	}
+}
+
+func TestExists(t *testing.T) {
+	positive := func(x int) bool { return x > 0 }
+
+	if !Ok(42).Exists(positive) {
+		t.Error("Ok(42).Exists(positive) should be true")
+	}
+	if Ok(-1).Exists(positive) {
+		t.Error("Ok(-1).Exists(positive) should be false")
+	}
+
+	called := false
+	if Err[int](errors.New("failed")).Exists(func(int) bool { called = true; return true }) {
+		t.Error("Err.Exists should be false")
+	}
+	if called {
+		t.Error("Err.Exists should not call the predicate")
+	}
+}
+
 func TestFilterFailureChain(t *testing.T) {
 	result := Ok(5).
 		Filter(func(x int) bool { return x > 10 }, errors.New("too small")).
@@ -409,3 +580,225 @@ func TestParseInt(t *testing.T) {
 		t.Error("'not a number' should be an error")
 	}
 }
+
+func TestAwait(t *testing.T) {
+	result := Await(context.Background(), func() Result[int] {
+		return Ok(42)
+	})
+	if !result.IsOk() || result.Unwrap() != 42 {
+		t.Errorf("Await result = %v, want Ok(42)", result)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+	defer close(release)
+
+	cancel()
+	cancelled := Await(ctx, func() Result[int] {
+		<-release
+		return Ok(1)
+	})
+	if !cancelled.IsErr() {
+		t.Fatal("Await with cancelled context should return Err")
+	}
+	if !errors.Is(cancelled.UnwrapErr(), context.Canceled) {
+		t.Errorf("Await error = %v, want context.Canceled", cancelled.UnwrapErr())
+	}
+}
+
+func TestAllOk(t *testing.T) {
+	if !AllOk([]Result[int]{Ok(1), Ok(2)}) {
+		t.Error("AllOk should be true when all results are Ok")
+	}
+	if AllOk([]Result[int]{Ok(1), Err[int](errors.New("test"))}) {
+		t.Error("AllOk should be false when a result is Err")
+	}
+	if !AllOk([]Result[int]{}) {
+		t.Error("AllOk should be true for empty input")
+	}
+}
+
+func TestAnyErr(t *testing.T) {
+	if err, found := AnyErr([]Result[int]{Ok(1), Ok(2)}); found || err != nil {
+		t.Errorf("AnyErr = (%v, %v), want (nil, false)", err, found)
+	}
+
+	first := errors.New("first")
+	second := errors.New("second")
+	err, found := AnyErr([]Result[int]{Ok(1), Err[int](first), Err[int](second)})
+	if !found || err != first {
+		t.Errorf("AnyErr = (%v, %v), want (first, true)", err, found)
+	}
+
+	if err, found := AnyErr([]Result[int]{}); found || err != nil {
+		t.Errorf("AnyErr on empty input = (%v, %v), want (nil, false)", err, found)
+	}
+}
+
+func TestAp(t *testing.T) {
+	double := Ok(func(x int) string { return strconv.Itoa(x * 2) })
+
+	applied := Ap(double, Ok(21))
+	if !applied.IsOk() || applied.Unwrap() != "42" {
+		t.Errorf("Ap(Ok(f), Ok(21)) = %v, want Ok(42)", applied)
+	}
+
+	valueErr := errors.New("bad value")
+	if got := Ap(double, Err[int](valueErr)).UnwrapErr(); got != valueErr {
+		t.Errorf("Ap(Ok(f), Err) error = %v, want %v", got, valueErr)
+	}
+
+	funcErr := errors.New("bad func")
+	if got := Ap(Err[func(int) string](funcErr), Ok(21)).UnwrapErr(); got != funcErr {
+		t.Errorf("Ap(Err, Ok) error = %v, want %v", got, funcErr)
+	}
+	if got := Ap(Err[func(int) string](funcErr), Err[int](valueErr)).UnwrapErr(); got != funcErr {
+		t.Errorf("Ap(Err, Err) error = %v, want function error %v", got, funcErr)
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	okOk := Flatten(Ok(Ok(42)))
+	if !okOk.IsOk() || okOk.Unwrap() != 42 {
+		t.Errorf("Flatten(Ok(Ok(42))) = %v, want Ok(42)", okOk)
+	}
+
+	innerErr := errors.New("inner")
+	okErr := Flatten(Ok(Err[int](innerErr)))
+	if okErr.UnwrapErr() != innerErr {
+		t.Errorf("Flatten(Ok(Err)) error = %v, want %v", okErr.UnwrapErr(), innerErr)
+	}
+
+	outerErr := errors.New("outer")
+	errOuter := Flatten(Err[Result[int]](outerErr))
+	if errOuter.UnwrapErr() != outerErr {
+		t.Errorf("Flatten(Err) error = %v, want %v", errOuter.UnwrapErr(), outerErr)
+	}
+}
+
+func TestZipWithOption(t *testing.T) {
+	zipped := ZipWithOption(Ok(1), option.Some("one"))
+	if !zipped.IsOk() || zipped.Unwrap() != (Pair[int, string]{First: 1, Second: "one"}) {
+		t.Errorf("ZipWithOption(Ok, Some) = %v, want Ok({1 one})", zipped)
+	}
+
+	missing := ZipWithOption(Ok(1), option.None[string]())
+	if !errors.Is(missing.UnwrapErr(), ErrMissingOption) {
+		t.Errorf("ZipWithOption(Ok, None) error = %v, want ErrMissingOption", missing.UnwrapErr())
+	}
+
+	err := errors.New("failed")
+	for _, o := range []option.Option[string]{option.Some("one"), option.None[string]()} {
+		if got := ZipWithOption(Err[int](err), o).UnwrapErr(); got != err {
+			t.Errorf("ZipWithOption(Err, %v) error = %v, want %v", o, got, err)
+		}
+	}
+}
+
+func TestCombine3(t *testing.T) {
+	ok := Combine3(Ok(1), Ok("two"), Ok(3.0))
+	if !ok.IsOk() {
+		t.Fatalf("Combine3 of Ok results should be Ok, got %v", ok.UnwrapErr())
+	}
+	tuple := ok.Unwrap()
+	if tuple.First != 1 || tuple.Second != "two" || tuple.Third != 3.0 {
+		t.Errorf("Combine3 = %+v, want {1 two 3}", tuple)
+	}
+
+	errA := errors.New("a")
+	errB := errors.New("b")
+	errC := errors.New("c")
+	tests := []struct {
+		name string
+		got  Result[Tuple3[int, string, float64]]
+		want error
+	}{
+		{"first", Combine3(Err[int](errA), Ok("two"), Ok(3.0)), errA},
+		{"middle", Combine3(Ok(1), Err[string](errB), Ok(3.0)), errB},
+		{"last", Combine3(Ok(1), Ok("two"), Err[float64](errC)), errC},
+		{"first of several", Combine3(Ok(1), Err[string](errB), Err[float64](errC)), errB},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.IsErr() || tt.got.UnwrapErr() != tt.want {
+				t.Errorf("Combine3 error = %v, want %v", tt.got.err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCombine4(t *testing.T) {
+	tuple := Combine4(Ok(1), Ok("two"), Ok(3.0), Ok(true)).Unwrap()
+	if tuple.First != 1 || tuple.Second != "two" || tuple.Third != 3.0 || !tuple.Fourth {
+		t.Errorf("Combine4 = %+v, want {1 two 3 true}", tuple)
+	}
+
+	err := errors.New("last")
+	if r := Combine4(Ok(1), Ok("two"), Ok(3.0), Err[bool](err)); r.UnwrapErr() != err {
+		t.Errorf("Combine4 error = %v, want %v", r.err, err)
+	}
+}
+
+func TestCatch(t *testing.T) {
+	ok := Catch(func() int { return 42 })
+	if !ok.IsOk() || ok.Unwrap() != 42 {
+		t.Errorf("Catch(normal) = %v, want Ok(42)", ok)
+	}
+
+	panicked := Catch(func() int { panic("boom") })
+	if !panicked.IsErr() || panicked.UnwrapErr().Error() != "panic: boom" {
+		t.Errorf("Catch(panic) = %v, want Err(panic: boom)", panicked)
+	}
+
+	sentinel := errors.New("sentinel")
+	wrapped := Catch(func() string { panic(sentinel) })
+	if !errors.Is(wrapped.UnwrapErr(), sentinel) {
+		t.Errorf("Catch(panic(err)) error = %v, want wrapping %v", wrapped.UnwrapErr(), sentinel)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	calls := 0
+	result := Retry(5, time.Millisecond, func() Result[int] {
+		calls++
+		if calls < 3 {
+			return Err[int](errors.New("transient"))
+		}
+		return Ok(calls)
+	})
+	if !result.IsOk() || result.Unwrap() != 3 {
+		t.Errorf("Retry result = %v, want Ok(3)", result)
+	}
+	if calls != 3 {
+		t.Errorf("Retry calls = %d, want 3", calls)
+	}
+
+	calls = 0
+	failed := Retry(4, time.Millisecond, func() Result[int] {
+		calls++
+		return Err[int](fmt.Errorf("attempt %d", calls))
+	})
+	if !failed.IsErr() || failed.UnwrapErr().Error() != "attempt 4" {
+		t.Errorf("Retry should return the last error, got %v", failed)
+	}
+	if calls != 4 {
+		t.Errorf("Retry calls = %d, want 4", calls)
+	}
+}
+
+func TestRetryExponential(t *testing.T) {
+	var times []time.Time
+	result := RetryExponential(3, 5*time.Millisecond, func() Result[int] {
+		times = append(times, time.Now())
+		return Err[int](errors.New("always"))
+	})
+	if !result.IsErr() {
+		t.Error("RetryExponential should return Err when all attempts fail")
+	}
+	if len(times) != 3 {
+		t.Fatalf("RetryExponential calls = %d, want 3", len(times))
+	}
+	if gap := times[2].Sub(times[1]); gap < 10*time.Millisecond {
+		t.Errorf("Second backoff = %v, want at least 10ms", gap)
+	}
+}
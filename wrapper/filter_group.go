@@ -0,0 +1,88 @@
+package wrapper
+
+import "errors"
+
+// Errors returned when validating and flattening filter groups
+var (
+	ErrEmptyNegatedGroup   = errors.New("negated filter group must have at least one member")
+	ErrNegatedGroupNotFlat = errors.New("negated filter group with several members cannot be flattened")
+)
+
+// FilterGroup combines filters and nested groups with AND. A negated group
+// matches when its combined condition does not.
+type FilterGroup struct {
+	Filters []Filter
+	Groups  []FilterGroup
+	Negated bool
+}
+
+// NewFilterGroup creates a group matching all of filters
+func NewFilterGroup(filters ...Filter) FilterGroup {
+	return FilterGroup{
+		Filters: filters,
+	}
+}
+
+// NotGroup creates a negated group, matching when not all of filters match
+func NotGroup(filters ...Filter) FilterGroup {
+	return FilterGroup{
+		Filters: filters,
+		Negated: true,
+	}
+}
+
+// WithGroup returns a copy of the group with group added as a member
+func (g FilterGroup) WithGroup(group FilterGroup) FilterGroup {
+	g.Groups = append(append([]FilterGroup(nil), g.Groups...), group)
+	return g
+}
+
+// Validate checks that every negated group, at any depth, has at least one member
+func (g FilterGroup) Validate() error {
+	if g.Negated && len(g.Filters)+len(g.Groups) == 0 {
+		return ErrEmptyNegatedGroup
+	}
+	for _, group := range g.Groups {
+		if err := group.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flatten returns filters that must all match, equivalent to the group.
+// A negated group with a single member becomes that member negated, and
+// double negation cancels out. NOT over several members has no flat form,
+// so it returns ErrNegatedGroupNotFlat.
+func (g FilterGroup) Flatten() ([]Filter, error) {
+	if err := g.Validate(); err != nil {
+		return nil, err
+	}
+	return g.flatten(false)
+}
+
+// flatten flattens the group, negating it once more if negate is set
+func (g FilterGroup) flatten(negate bool) ([]Filter, error) {
+	negated := g.Negated != negate
+
+	if negated {
+		switch {
+		case len(g.Filters) == 1 && len(g.Groups) == 0:
+			return []Filter{g.Filters[0].Not()}, nil
+		case len(g.Filters) == 0 && len(g.Groups) == 1:
+			return g.Groups[0].flatten(true)
+		default:
+			return nil, ErrNegatedGroupNotFlat
+		}
+	}
+
+	filters := append([]Filter(nil), g.Filters...)
+	for _, group := range g.Groups {
+		flat, err := group.flatten(false)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, flat...)
+	}
+	return filters, nil
+}
@@ -0,0 +1,73 @@
+package wrapper
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFilterNot(t *testing.T) {
+	filter := NewFilter("status", "deleted")
+	if filter.Negated() {
+		t.Error("New filter should not be negated")
+	}
+	if !filter.Not().Negated() {
+		t.Error("Not should negate the filter")
+	}
+	if filter.Not().Not().Negated() {
+		t.Error("Double Not should cancel out")
+	}
+}
+
+func TestFilterGroupFlattenNegatedSingle(t *testing.T) {
+	group := NotGroup(NewFilter("status", "deleted"))
+
+	flat, err := group.Flatten()
+	if err != nil {
+		t.Fatalf("Flatten failed: %v", err)
+	}
+
+	expected := []Filter{NewFilter("status", "deleted").Not()}
+	if !reflect.DeepEqual(flat, expected) {
+		t.Errorf("Expected %v, got %v", expected, flat)
+	}
+}
+
+func TestFilterGroupFlattenNested(t *testing.T) {
+	group := NewFilterGroup(NewFilter("tenant", "acme")).
+		WithGroup(NotGroup(NewFilter("status", "deleted"))).
+		WithGroup(FilterGroup{Negated: true}.WithGroup(NotGroup(NewFilter("archived", true))))
+
+	flat, err := group.Flatten()
+	if err != nil {
+		t.Fatalf("Flatten failed: %v", err)
+	}
+
+	expected := []Filter{
+		NewFilter("tenant", "acme"),
+		NewFilter("status", "deleted").Not(),
+		NewFilter("archived", true),
+	}
+	if !reflect.DeepEqual(flat, expected) {
+		t.Errorf("Expected %v, got %v", expected, flat)
+	}
+}
+
+func TestFilterGroupValidate(t *testing.T) {
+	empty := NewFilterGroup(NewFilter("tenant", "acme")).WithGroup(NotGroup())
+	if err := empty.Validate(); !errors.Is(err, ErrEmptyNegatedGroup) {
+		t.Errorf("Expected ErrEmptyNegatedGroup, got %v", err)
+	}
+	if _, err := empty.Flatten(); !errors.Is(err, ErrEmptyNegatedGroup) {
+		t.Errorf("Expected Flatten to validate, got %v", err)
+	}
+
+	if err := NewFilterGroup().Validate(); err != nil {
+		t.Errorf("Empty non-negated group should be valid, got %v", err)
+	}
+
+	multi := NotGroup(NewFilter("status", "deleted"), NewFilter("archived", true))
+	if _, err := multi.Flatten(); !errors.Is(err, ErrNegatedGroupNotFlat) {
+		t.Errorf("Expected ErrNegatedGroupNotFlat, got %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package wrapper
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Query parameters read by ParseQueryWrapper
+const (
+	LimitParam   = "limit"
+	OffsetParam  = "offset"
+	SortParam    = "sort"
+	FieldsParam  = "fields"
+	ExcludeParam = "exclude"
+	FilterParam  = "filter"
+)
+
+// ParseQueryWrapper builds a QueryWrapper for query from the request's URL
+// parameters and context:
+//
+//	limit=20&offset=40     pagination, unset when both are absent
+//	sort=name or sort=-name ascending or descending sort, field must be in allowedSort
+//	fields=id,name         projection
+//	exclude=address.street fields removed from the projection
+//	filter[status]=active  filters, fields must be in allowedFilter
+func ParseQueryWrapper[Q any](r *http.Request, query Q, allowedSort, allowedFilter []string) (QueryWrapper[Q], error) {
+	values := r.URL.Query()
+	builder := NewQueryWrapperBuilder[Q]().
+		WithContext(r.Context()).
+		WithQuery(query).
+		withProjection(parseProjection(values))
+
+	if values.Has(LimitParam) || values.Has(OffsetParam) {
+		pagination, err := parsePagination(values)
+		if err != nil {
+			return QueryWrapper[Q]{}, err
+		}
+		builder.WithPagination(pagination)
+	}
+
+	if values.Has(SortParam) {
+		sortBy, err := parseSortBy(values.Get(SortParam), allowedSort)
+		if err != nil {
+			return QueryWrapper[Q]{}, err
+		}
+		builder.WithSortBy(sortBy)
+	}
+
+	filters, err := parseFilters(values)
+	if err != nil {
+		return QueryWrapper[Q]{}, err
+	}
+	if err := ValidateFilters(filters, allowedFilter); err != nil {
+		return QueryWrapper[Q]{}, err
+	}
+	builder.WithFilter(filters)
+
+	return builder.Build(), nil
+}
+
+// parsePagination reads limit and offset, using the first page defaults for a missing one
+func parsePagination(values url.Values) (Pagination, error) {
+	pagination := NewFirstPagePagination()
+
+	if raw := values.Get(LimitParam); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil {
+			return Pagination{}, fmt.Errorf("invalid %s %q: must be an integer", LimitParam, raw)
+		}
+		pagination.limit = limit
+	}
+	if raw := values.Get(OffsetParam); raw != "" {
+		offset, err := strconv.Atoi(raw)
+		if err != nil {
+			return Pagination{}, fmt.Errorf("invalid %s %q: must be an integer", OffsetParam, raw)
+		}
+		pagination.offset = offset
+	}
+
+	if err := pagination.Validate(); err != nil {
+		return Pagination{}, err
+	}
+	return pagination, nil
+}
+
+// parseSortBy parses "field" or "-field" and checks the field is allowed
+func parseSortBy(raw string, allowed []string) (SortBy, error) {
+	sortBy := NewAscendingSortBy(raw)
+	if field, ok := strings.CutPrefix(raw, "-"); ok {
+		sortBy = NewDescendingSortBy(field)
+	}
+
+	if err := sortBy.Validate(); err != nil {
+		return SortBy{}, err
+	}
+	if !slices.Contains(allowed, sortBy.field) {
+		return SortBy{}, fmt.Errorf("sort field not allowed: %s", sortBy.field)
+	}
+	return sortBy, nil
+}
+
+// parseProjection reads comma separated included and excluded field lists, empty when absent
+func parseProjection(values url.Values) Projection {
+	fields := parseFieldList(values.Get(FieldsParam))
+	exclude := parseFieldList(values.Get(ExcludeParam))
+	if len(fields) == 0 && len(exclude) == 0 {
+		return NewEmptyProjection()
+	}
+	return NewSparseProjection(fields, exclude)
+}
+
+// parseFieldList splits a comma separated list, dropping empty entries
+func parseFieldList(raw string) []string {
+	var fields []string
+	for _, field := range strings.Split(raw, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// parseFilters reads filter[field]=value parameters in field order, one filter per value
+func parseFilters(values url.Values) ([]Filter, error) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	var filters []Filter
+	for _, key := range keys {
+		rest, ok := strings.CutPrefix(key, FilterParam+"[")
+		if !ok {
+			continue
+		}
+		field, ok := strings.CutSuffix(rest, "]")
+		if !ok || field == "" {
+			return nil, fmt.Errorf("invalid filter parameter %q: expected %s[field]", key, FilterParam)
+		}
+		for _, value := range values[key] {
+			filters = append(filters, NewFilter(field, value))
+		}
+	}
+	return filters, nil
+}
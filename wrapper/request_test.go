@@ -0,0 +1,105 @@
+package wrapper
+
+import (
+	"context"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+type requestKey struct{}
+
+func TestParseQueryWrapper(t *testing.T) {
+	req := httptest.NewRequest("GET", "/users?limit=20&offset=40&sort=-created_at&fields=id,name&filter[status]=active&filter[role]=admin&filter[role]=owner", nil)
+	req = req.WithContext(context.WithValue(req.Context(), requestKey{}, "request-1"))
+
+	qw, err := ParseQueryWrapper(req, TestQuery{Name: "users"}, []string{"created_at", "name"}, []string{"status", "role"})
+	if err != nil {
+		t.Fatalf("ParseQueryWrapper failed: %v", err)
+	}
+
+	if qw.Context.Value(requestKey{}) != "request-1" {
+		t.Error("Expected request context to be used")
+	}
+	if qw.Query.Name != "users" {
+		t.Error("Query not set correctly")
+	}
+	if qw.Pagination().Limit() != 20 || qw.Pagination().Offset() != 40 {
+		t.Errorf("Unexpected pagination %+v", qw.Pagination())
+	}
+	if qw.SortBy().Field() != "created_at" || qw.SortBy().Direction() != Desc {
+		t.Errorf("Unexpected sort %+v", qw.SortBy())
+	}
+	if !reflect.DeepEqual(qw.Projection().Fields(), []string{"id", "name"}) {
+		t.Errorf("Unexpected projection %v", qw.Projection().Fields())
+	}
+
+	expected := []Filter{
+		NewFilter("role", "admin"),
+		NewFilter("role", "owner"),
+		NewFilter("status", "active"),
+	}
+	if !reflect.DeepEqual(qw.Filter(), expected) {
+		t.Errorf("Expected filters %v, got %v", expected, qw.Filter())
+	}
+}
+
+func TestParseQueryWrapperExclude(t *testing.T) {
+	req := httptest.NewRequest("GET", "/users?exclude=address.street,email", nil)
+
+	qw, err := ParseQueryWrapper(req, TestQuery{}, nil, nil)
+	if err != nil {
+		t.Fatalf("ParseQueryWrapper failed: %v", err)
+	}
+
+	resolved := qw.Projection().Resolve([]string{"id", "email", "address.street", "address.city"})
+	if !reflect.DeepEqual(resolved, []string{"id", "address.city"}) {
+		t.Errorf("Unexpected resolved projection %v", resolved)
+	}
+}
+
+func TestParseQueryWrapperDefaults(t *testing.T) {
+	req := httptest.NewRequest("GET", "/users?sort=name", nil)
+
+	qw, err := ParseQueryWrapper(req, TestQuery{}, []string{"name"}, nil)
+	if err != nil {
+		t.Fatalf("ParseQueryWrapper failed: %v", err)
+	}
+
+	if qw.PaginationOpt().IsSome() {
+		t.Error("Expected pagination to be unset")
+	}
+	if !qw.SortBy().IsAscending() {
+		t.Error("Expected ascending sort")
+	}
+	if len(qw.Projection().Fields()) != 0 || len(qw.Filter()) != 0 {
+		t.Error("Expected empty projection and filters")
+	}
+}
+
+func TestParseQueryWrapperErrors(t *testing.T) {
+	allowedSort := []string{"name"}
+	allowedFilter := []string{"status"}
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"non-numeric limit", "limit=ten"},
+		{"negative offset", "offset=-1"},
+		{"zero limit", "limit=0"},
+		{"disallowed sort", "sort=-password"},
+		{"empty sort", "sort="},
+		{"disallowed filter", "filter[secret]=x"},
+		{"malformed filter", "filter[status=active"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/users?"+tt.query, nil)
+			if _, err := ParseQueryWrapper(req, TestQuery{}, allowedSort, allowedFilter); err == nil {
+				t.Errorf("Expected error for %q", tt.query)
+			}
+		})
+	}
+}
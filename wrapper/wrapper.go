@@ -3,6 +3,19 @@ package wrapper
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/l00pss/helpme/option"
+)
+
+// Validation errors returned by SortBy.Validate and Pagination.Validate
+var (
+	ErrEmptySortField = errors.New("sort field cannot be empty")
+	ErrInvalidLimit   = errors.New("limit must be positive")
+	ErrNegativeOffset = errors.New("offset cannot be negative")
 )
 
 type Void struct{}
@@ -12,8 +25,8 @@ type QueryWrapper[Q any] struct {
 	Context    context.Context
 	Query      Q
 	projection Projection
-	pagination Pagination
-	sortBy     SortBy
+	pagination option.Option[Pagination]
+	sortBy     option.Option[SortBy]
 	filter     []Filter
 }
 
@@ -22,13 +35,23 @@ func (qw QueryWrapper[Q]) Projection() Projection {
 	return qw.projection
 }
 
-// Pagination returns the pagination
+// Pagination returns the pagination, defaulting to the first page when none was requested
 func (qw QueryWrapper[Q]) Pagination() Pagination {
+	return qw.pagination.GetOrElseFunc(NewFirstPagePagination)
+}
+
+// PaginationOpt returns the pagination, or None when none was requested
+func (qw QueryWrapper[Q]) PaginationOpt() option.Option[Pagination] {
 	return qw.pagination
 }
 
-// SortBy returns the sort configuration
+// SortBy returns the sort configuration, or an empty SortBy when none was requested
 func (qw QueryWrapper[Q]) SortBy() SortBy {
+	return qw.sortBy.GetOrElse(SortBy{})
+}
+
+// SortByOpt returns the sort configuration, or None when none was requested
+func (qw QueryWrapper[Q]) SortByOpt() option.Option[SortBy] {
 	return qw.sortBy
 }
 
@@ -37,13 +60,68 @@ func (qw QueryWrapper[Q]) Filter() []Filter {
 	return qw.filter
 }
 
+// ValidateFilters checks that every filter field is part of the projection
+// and not excluded by it. An empty include list allows all fields that are
+// not excluded.
+func (qw QueryWrapper[Q]) ValidateFilters() error {
+	var disallowed []string
+	for _, f := range qw.filter {
+		allowed := len(qw.projection.fields) == 0 || slices.Contains(qw.projection.fields, f.field)
+		if (!allowed || qw.projection.excludes(f.field)) && !slices.Contains(disallowed, f.field) {
+			disallowed = append(disallowed, f.field)
+		}
+	}
+	if len(disallowed) > 0 {
+		return fmt.Errorf("filter fields not allowed: %s", strings.Join(disallowed, ", "))
+	}
+	return nil
+}
+
+// CacheKey returns a deterministic key for the query shape: pagination, sort,
+// filters and projection. Equivalent wrappers get the same key regardless of
+// filter order. The Query value itself is not part of the key.
+func (qw QueryWrapper[Q]) CacheKey() string {
+	pagination := ""
+	if p, ok := qw.pagination.Get(); ok {
+		pagination = p.Key()
+	}
+	sortBy := ""
+	if s, ok := qw.sortBy.Get(); ok {
+		sortBy = s.Key()
+	}
+	return strings.Join([]string{
+		"page=" + pagination,
+		"sort=" + sortBy,
+		"filter=" + FiltersKey(qw.filter),
+		"fields=" + strings.Join(qw.projection.fields, ","),
+		"exclude=" + strings.Join(qw.projection.exclude, ","),
+	}, "|")
+}
+
+// Validate validates the query if it implements Validatable
+func (qw QueryWrapper[Q]) Validate() error {
+	return validate(&qw.Query)
+}
+
+// Clone returns a copy of the wrapper that does not share the filter and projection slices
+func (qw QueryWrapper[Q]) Clone() QueryWrapper[Q] {
+	return QueryWrapper[Q]{
+		Context:    qw.Context,
+		Query:      qw.Query,
+		projection: qw.projection.clone(),
+		pagination: qw.pagination,
+		sortBy:     qw.sortBy,
+		filter:     slices.Clone(qw.filter),
+	}
+}
+
 func NewQueryWrapper[T any](ctx context.Context, query T, projection Projection, pagination Pagination, sortBy SortBy, filter []Filter) QueryWrapper[T] {
 	return QueryWrapper[T]{
 		Context:    ctx,
 		Query:      query,
 		projection: projection,
-		pagination: pagination,
-		sortBy:     sortBy,
+		pagination: option.Some(pagination),
+		sortBy:     option.Some(sortBy),
 		filter:     filter,
 	}
 }
@@ -52,8 +130,8 @@ type QueryWrapperBuilder[Q any] struct {
 	ctx        context.Context
 	query      Q
 	projection Projection
-	pagination Pagination
-	sortBy     SortBy
+	pagination option.Option[Pagination]
+	sortBy     option.Option[SortBy]
 	filter     []Filter
 }
 
@@ -77,12 +155,12 @@ func (b *QueryWrapperBuilder[T]) withProjection(projection Projection) *QueryWra
 }
 
 func (b *QueryWrapperBuilder[T]) WithPagination(pagination Pagination) *QueryWrapperBuilder[T] {
-	b.pagination = pagination
+	b.pagination = option.Some(pagination)
 	return b
 }
 
 func (b *QueryWrapperBuilder[T]) WithSortBy(sortBy SortBy) *QueryWrapperBuilder[T] {
-	b.sortBy = sortBy
+	b.sortBy = option.Some(sortBy)
 	return b
 }
 
@@ -91,6 +169,24 @@ func (b *QueryWrapperBuilder[T]) WithFilter(filter []Filter) *QueryWrapperBuilde
 	return b
 }
 
+// Reset clears every field so the builder can be reused
+func (b *QueryWrapperBuilder[T]) Reset() *QueryWrapperBuilder[T] {
+	*b = QueryWrapperBuilder[T]{}
+	return b
+}
+
+// Clone returns an independent copy of the builder's current state
+func (b *QueryWrapperBuilder[T]) Clone() *QueryWrapperBuilder[T] {
+	return &QueryWrapperBuilder[T]{
+		ctx:        b.ctx,
+		query:      b.query,
+		projection: b.projection.clone(),
+		pagination: b.pagination,
+		sortBy:     b.sortBy,
+		filter:     slices.Clone(b.filter),
+	}
+}
+
 func (b *QueryWrapperBuilder[T]) Build() QueryWrapper[T] {
 	return QueryWrapper[T]{
 		Context:    b.ctx,
@@ -102,6 +198,36 @@ func (b *QueryWrapperBuilder[T]) Build() QueryWrapper[T] {
 	}
 }
 
+// queryWrapperKey is the context key for a QueryWrapper, distinct per query type
+type queryWrapperKey[Q any] struct{}
+
+// WithQueryWrapper returns a copy of ctx carrying qw
+func WithQueryWrapper[Q any](ctx context.Context, qw QueryWrapper[Q]) context.Context {
+	return context.WithValue(ctx, queryWrapperKey[Q]{}, qw)
+}
+
+// QueryWrapperFromContext returns the QueryWrapper stored in ctx, if any
+func QueryWrapperFromContext[Q any](ctx context.Context) (QueryWrapper[Q], bool) {
+	qw, ok := ctx.Value(queryWrapperKey[Q]{}).(QueryWrapper[Q])
+	return qw, ok
+}
+
+// Validatable is implemented by queries and commands that can check their own data
+type Validatable interface {
+	Validate() error
+}
+
+// validate calls Validate on the value behind ptr if it, or ptr itself, implements Validatable
+func validate[T any](ptr *T) error {
+	if v, ok := any(*ptr).(Validatable); ok {
+		return v.Validate()
+	}
+	if v, ok := any(ptr).(Validatable); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
 type CommandWrapper[C any] struct {
 	Context context.Context
 	Command C
@@ -114,6 +240,11 @@ func NewCommandWrapper[T any](ctx context.Context, command T) CommandWrapper[T]
 	}
 }
 
+// Validate validates the command if it implements Validatable
+func (cw CommandWrapper[C]) Validate() error {
+	return validate(&cw.Command)
+}
+
 type CommandWrapperBuilder[C any] struct {
 	ctx     context.Context
 	command C
@@ -140,43 +271,63 @@ func (b *CommandWrapperBuilder[C]) Build() CommandWrapper[C] {
 	}
 }
 
+// Direction represents the sort order
+type Direction string
+
+const (
+	Asc  Direction = "asc"
+	Desc Direction = "desc"
+)
+
 type SortBy struct {
 	field     string
-	ascending bool
+	direction Direction
 }
 
-func NewSortBy(field string, ascending bool) SortBy {
+// NewSort creates a new SortBy with the given direction
+func NewSort(field string, dir Direction) SortBy {
 	return SortBy{
 		field:     field,
-		ascending: ascending,
+		direction: dir,
 	}
 }
 
-func NewAscendingSortBy(field string) SortBy {
-	return SortBy{
-		field:     field,
-		ascending: true,
+func NewSortBy(field string, ascending bool) SortBy {
+	if ascending {
+		return NewSort(field, Asc)
 	}
+	return NewSort(field, Desc)
+}
+
+func NewAscendingSortBy(field string) SortBy {
+	return NewSort(field, Asc)
 }
 
 func NewDescendingSortBy(field string) SortBy {
-	return SortBy{
-		field:     field,
-		ascending: false,
-	}
+	return NewSort(field, Desc)
 }
 
 func (s SortBy) Field() string {
 	return s.field
 }
 
+// Direction returns the sort direction
+func (s SortBy) Direction() Direction {
+	return s.direction
+}
+
 func (s SortBy) IsAscending() bool {
-	return s.ascending
+	return s.direction == Asc
+}
+
+// Key returns a deterministic string for the sort, e.g. "name:asc"
+func (s SortBy) Key() string {
+	return s.field + ":" + string(s.direction)
 }
 
 func (s SortBy) Validate() error {
 	if s.field == "" {
-		return errors.New("sort field cannot be empty")
+		return ErrEmptySortField
 	}
 	return nil
 }
@@ -193,6 +344,17 @@ func NewPagination(limit, offset int) Pagination {
 	}
 }
 
+// NewPageNumberPagination creates a pagination for the 1-based page of the given size.
+// Page and size are clamped to at least 1.
+func NewPageNumberPagination(page, size int) Pagination {
+	page = max(page, 1)
+	size = max(size, 1)
+	return Pagination{
+		limit:  size,
+		offset: (page - 1) * size,
+	}
+}
+
 func NewFirstPagePagination() Pagination {
 	return Pagination{
 		limit:  10,
@@ -208,6 +370,14 @@ func (p Pagination) Offset() int {
 	return p.offset
 }
 
+// PageNumber returns the 1-based page the offset falls on, or 0 if the limit is not positive
+func (p Pagination) PageNumber() int {
+	if p.limit <= 0 {
+		return 0
+	}
+	return p.offset/p.limit + 1
+}
+
 func (p Pagination) HasNext(totalCount int) bool {
 	return p.offset+p.limit < totalCount
 }
@@ -219,19 +389,72 @@ func (p Pagination) NextPage() Pagination {
 	}
 }
 
+// Key returns a deterministic string for the pagination, e.g. "10:20" for limit 10 and offset 20
+func (p Pagination) Key() string {
+	return strconv.Itoa(p.limit) + ":" + strconv.Itoa(p.offset)
+}
+
 func (p Pagination) Validate() error {
 	if p.limit <= 0 {
-		return errors.New("limit must be positive")
+		return ErrInvalidLimit
 	}
 	if p.offset < 0 {
-		return errors.New("offset cannot be negative")
+		return ErrNegativeOffset
+	}
+	return nil
+}
+
+// ValidateWithMax validates the pagination and rejects limits above maxLimit
+func (p Pagination) ValidateWithMax(maxLimit int) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	if p.limit > maxLimit {
+		return fmt.Errorf("limit cannot exceed %d", maxLimit)
 	}
 	return nil
 }
 
+// Clamp returns a pagination with the limit reduced to maxLimit if it exceeds it
+func (p Pagination) Clamp(maxLimit int) Pagination {
+	if p.limit > maxLimit {
+		p.limit = maxLimit
+	}
+	return p
+}
+
+// PageInfo describes a page within a result set, as consumed by UI paginators
+type PageInfo struct {
+	CurrentPage int
+	TotalPages  int
+	HasNext     bool
+	HasPrev     bool
+	TotalCount  int
+	PageSize    int
+}
+
+// PageInfo returns the page metadata for totalCount results. CurrentPage is
+// 1-based. A non-positive limit yields zero pages.
+func (p Pagination) PageInfo(totalCount int) PageInfo {
+	info := PageInfo{
+		HasPrev:    p.offset > 0,
+		TotalCount: totalCount,
+		PageSize:   p.limit,
+	}
+	if p.limit <= 0 {
+		return info
+	}
+
+	info.CurrentPage = p.PageNumber()
+	info.TotalPages = (totalCount + p.limit - 1) / p.limit
+	info.HasNext = p.HasNext(totalCount)
+	return info
+}
+
 type Filter struct {
-	field string
-	value any
+	field   string
+	value   any
+	negated bool
 }
 
 func NewFilter(field string, value any) Filter {
@@ -249,8 +472,67 @@ func (f Filter) Value() any {
 	return f.value
 }
 
+// Negated reports whether the filter matches values other than Value
+func (f Filter) Negated() bool {
+	return f.negated
+}
+
+// Not returns a copy of the filter with the opposite meaning
+func (f Filter) Not() Filter {
+	f.negated = !f.negated
+	return f
+}
+
+// ValidateFilters returns an error listing the filter fields that are not in allowed
+func ValidateFilters(filters []Filter, allowed []string) error {
+	var disallowed []string
+	for _, f := range filters {
+		if !slices.Contains(allowed, f.field) && !slices.Contains(disallowed, f.field) {
+			disallowed = append(disallowed, f.field)
+		}
+	}
+	if len(disallowed) > 0 {
+		return fmt.Errorf("filter fields not allowed: %s", strings.Join(disallowed, ", "))
+	}
+	return nil
+}
+
+// FiltersKey returns a deterministic string for filters that does not depend
+// on their order. Values are formatted with %#v, so 1 and "1" differ.
+func FiltersKey(filters []Filter) string {
+	parts := make([]string, len(filters))
+	for i, f := range filters {
+		op := "="
+		if f.negated {
+			op = "!="
+		}
+		parts[i] = fmt.Sprintf("%s%s%#v", f.field, op, f.value)
+	}
+	slices.Sort(parts)
+	return strings.Join(parts, "&")
+}
+
+// FiltersToMap indexes filters by field, the last filter wins on duplicate fields
+func FiltersToMap(filters []Filter) map[string]Filter {
+	result := make(map[string]Filter, len(filters))
+	for _, f := range filters {
+		result[f.field] = f
+	}
+	return result
+}
+
+// FiltersByField groups filters by field, keeping every filter in order
+func FiltersByField(filters []Filter) map[string][]Filter {
+	result := make(map[string][]Filter)
+	for _, f := range filters {
+		result[f.field] = append(result[f.field], f)
+	}
+	return result
+}
+
 type Projection struct {
-	fields []string
+	fields  []string
+	exclude []string
 }
 
 // NewProjection creates a new Projection with the given fields
@@ -267,15 +549,69 @@ func NewEmptyProjection() Projection {
 	}
 }
 
+// NewSparseProjection creates a projection including fields, or every field if
+// fields is empty, minus the excluded fields and their nested subfields
+func NewSparseProjection(fields, exclude []string) Projection {
+	return Projection{
+		fields:  fields,
+		exclude: exclude,
+	}
+}
+
 func (p Projection) Fields() []string {
 	return p.fields
 }
 
+// Exclude returns the excluded fields
+func (p Projection) Exclude() []string {
+	return p.exclude
+}
+
+// Resolve returns the effective fields: the included fields, or allFields if
+// none were included, without excluded fields. Excluding "address" also
+// removes nested fields such as "address.street".
+func (p Projection) Resolve(allFields []string) []string {
+	fields := p.fields
+	if len(fields) == 0 {
+		fields = allFields
+	}
+
+	resolved := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if !p.excludes(field) {
+			resolved = append(resolved, field)
+		}
+	}
+	return resolved
+}
+
+// excludes reports whether field or one of its parents is excluded
+func (p Projection) excludes(field string) bool {
+	for _, excluded := range p.exclude {
+		if field == excluded || strings.HasPrefix(field, excluded+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// clone returns a copy of the projection that does not share its slices
+func (p Projection) clone() Projection {
+	return Projection{
+		fields:  slices.Clone(p.fields),
+		exclude: slices.Clone(p.exclude),
+	}
+}
+
 type Page[R any] struct {
 	Results []R
 	Offset  int
 	Limit   int
 	HasNext bool
+	// HasPrev, NextCursor and PrevCursor are set in keyset (cursor) pagination mode
+	HasPrev    bool
+	NextCursor string
+	PrevCursor string
 }
 
 func (r *Page[R]) Next() bool {
@@ -290,11 +626,48 @@ func (r *Page[R]) HasData() bool {
 	return len(r.Results) > 0
 }
 
+// Each calls f for every result with its index
+func (r Page[R]) Each(f func(i int, result R)) {
+	for i, result := range r.Results {
+		f(i, result)
+	}
+}
+
+// Filter returns a page with only the results matching pred, keeping the pagination metadata
+func (r Page[R]) Filter(pred func(R) bool) Page[R] {
+	results := make([]R, 0, len(r.Results))
+	for _, result := range r.Results {
+		if pred(result) {
+			results = append(results, result)
+		}
+	}
+	r.Results = results
+	return r
+}
+
+// GroupBy buckets the page results by keyFn, preserving their order within each bucket
+func GroupBy[R any, K comparable](p Page[R], keyFn func(R) K) map[K][]R {
+	groups := make(map[K][]R)
+	for _, result := range p.Results {
+		key := keyFn(result)
+		groups[key] = append(groups[key], result)
+	}
+	return groups
+}
+
+// Repository finds a page of results for a wrapped query
+type Repository[Q, R any] interface {
+	Find(qw QueryWrapper[Q]) (Page[R], error)
+}
+
 type PagesBuilder[R any] struct {
-	results []R
-	offset  int
-	limit   int
-	hasNext bool
+	results    []R
+	offset     int
+	limit      int
+	hasNext    bool
+	hasPrev    bool
+	nextCursor string
+	prevCursor string
 }
 
 func NewPagesBuilder[R any]() *PagesBuilder[R] {
@@ -321,11 +694,33 @@ func (p *PagesBuilder[R]) HasNext(hasNext bool) *PagesBuilder[R] {
 	return p
 }
 
+func (p *PagesBuilder[R]) HasPrev(hasPrev bool) *PagesBuilder[R] {
+	p.hasPrev = hasPrev
+	return p
+}
+
+// NextCursor sets the cursor for the next page and marks the page as having one
+func (p *PagesBuilder[R]) NextCursor(cursor string) *PagesBuilder[R] {
+	p.nextCursor = cursor
+	p.hasNext = cursor != ""
+	return p
+}
+
+// PrevCursor sets the cursor for the previous page and marks the page as having one
+func (p *PagesBuilder[R]) PrevCursor(cursor string) *PagesBuilder[R] {
+	p.prevCursor = cursor
+	p.hasPrev = cursor != ""
+	return p
+}
+
 func (p *PagesBuilder[R]) Build() Page[R] {
 	return Page[R]{
-		Results: p.results,
-		Offset:  p.offset,
-		Limit:   p.limit,
-		HasNext: p.hasNext,
+		Results:    p.results,
+		Offset:     p.offset,
+		Limit:      p.limit,
+		HasNext:    p.hasNext,
+		HasPrev:    p.hasPrev,
+		NextCursor: p.nextCursor,
+		PrevCursor: p.prevCursor,
 	}
 }
@@ -2,7 +2,10 @@ package wrapper
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"net/http/httptest"
+	"reflect"
 	"testing"
 )
 
@@ -16,6 +19,17 @@ type TestCommand struct {
 	Data   map[string]interface{}
 }
 
+type ValidatedCommand struct {
+	Email string
+}
+
+func (c ValidatedCommand) Validate() error {
+	if c.Email == "" {
+		return errors.New("email is required")
+	}
+	return nil
+}
+
 type TestResult struct {
 	ID   int
 	Name string
@@ -93,6 +107,189 @@ func TestQueryWrapperBuilder(t *testing.T) {
 	}
 }
 
+func TestQueryWrapperBuilderUnsetSortAndPagination(t *testing.T) {
+	wrapper := NewQueryWrapperBuilder[TestQuery]().
+		WithQuery(TestQuery{Name: "unset"}).
+		Build()
+
+	if wrapper.SortByOpt().IsSome() {
+		t.Error("SortByOpt should be None when no sort was set")
+	}
+	if wrapper.PaginationOpt().IsSome() {
+		t.Error("PaginationOpt should be None when no pagination was set")
+	}
+	if wrapper.Pagination() != NewFirstPagePagination() {
+		t.Error("Pagination should default to the first page when unset")
+	}
+	if wrapper.SortBy().Field() != "" {
+		t.Error("SortBy should default to an empty sort when unset")
+	}
+
+	explicit := NewQueryWrapperBuilder[TestQuery]().
+		WithPagination(NewFirstPagePagination()).
+		WithSortBy(SortBy{}).
+		Build()
+
+	if explicit.PaginationOpt().IsNone() {
+		t.Error("PaginationOpt should be Some when set explicitly, even to the default")
+	}
+	if explicit.SortByOpt().IsNone() {
+		t.Error("SortByOpt should be Some when set explicitly, even to the zero value")
+	}
+}
+
+func TestQueryWrapperBuilderReset(t *testing.T) {
+	builder := NewQueryWrapperBuilder[TestQuery]()
+
+	first := builder.
+		WithContext(context.Background()).
+		WithQuery(TestQuery{Name: "first"}).
+		WithPagination(NewPagination(50, 0)).
+		WithSortBy(NewAscendingSortBy("name")).
+		WithFilter([]Filter{NewFilter("status", "active")}).
+		Build()
+
+	second := builder.Reset().
+		WithQuery(TestQuery{Name: "second"}).
+		Build()
+
+	if first.Query.Name != "first" || first.Pagination().Limit() != 50 {
+		t.Error("Reset should not affect wrappers already built")
+	}
+	if second.Query.Name != "second" {
+		t.Error("Builder query not set correctly after Reset")
+	}
+	if second.Context != nil {
+		t.Error("Reset should clear the context")
+	}
+	if second.PaginationOpt().IsSome() || second.SortByOpt().IsSome() {
+		t.Error("Reset should clear pagination and sort")
+	}
+	if len(second.Filter()) != 0 {
+		t.Error("Reset should clear the filters")
+	}
+}
+
+func TestQueryWrapperBuilderClone(t *testing.T) {
+	base := NewQueryWrapperBuilder[TestQuery]().
+		WithQuery(TestQuery{Name: "base"}).
+		WithFilter([]Filter{NewFilter("status", "active")})
+
+	clone := base.Clone().WithPagination(NewPagination(5, 0))
+	clone.filter[0] = NewFilter("status", "deleted")
+
+	original := base.Build()
+	derived := clone.Build()
+
+	if original.PaginationOpt().IsSome() {
+		t.Error("Changes to the clone should not affect the original builder")
+	}
+	if original.Filter()[0].Value() != "active" {
+		t.Error("Clone should not share filters with the original builder")
+	}
+	if derived.Query.Name != "base" || derived.Pagination().Limit() != 5 {
+		t.Error("Clone should keep the original state and its own changes")
+	}
+}
+
+func TestQueryWrapperContext(t *testing.T) {
+	qw := NewQueryWrapperBuilder[TestQuery]().
+		WithQuery(TestQuery{Name: "ctx", Age: 25}).
+		WithPagination(NewPagination(5, 10)).
+		Build()
+
+	ctx := WithQueryWrapper(context.Background(), qw)
+
+	got, ok := QueryWrapperFromContext[TestQuery](ctx)
+	if !ok {
+		t.Fatal("QueryWrapperFromContext should find the stored wrapper")
+	}
+	if got.Query.Name != "ctx" || got.Pagination().Offset() != 10 {
+		t.Error("QueryWrapperFromContext should return the stored wrapper")
+	}
+
+	if _, ok := QueryWrapperFromContext[string](ctx); ok {
+		t.Error("QueryWrapperFromContext should not match a different query type")
+	}
+	if _, ok := QueryWrapperFromContext[TestQuery](context.Background()); ok {
+		t.Error("QueryWrapperFromContext should report false for an empty context")
+	}
+}
+
+func TestQueryWrapperCacheKey(t *testing.T) {
+	ctx := context.Background()
+	build := func(filters []Filter) QueryWrapper[TestQuery] {
+		return NewQueryWrapperBuilder[TestQuery]().
+			WithContext(ctx).
+			WithPagination(NewPagination(10, 20)).
+			WithSortBy(NewDescendingSortBy("created_at")).
+			WithFilter(filters).
+			Build()
+	}
+
+	a := build([]Filter{NewFilter("status", "active"), NewFilter("age", 30), NewFilter("role", "admin").Not()})
+	b := build([]Filter{NewFilter("role", "admin").Not(), NewFilter("status", "active"), NewFilter("age", 30)})
+	if a.CacheKey() != b.CacheKey() {
+		t.Errorf("Expected equal keys regardless of filter order:\n%s\n%s", a.CacheKey(), b.CacheKey())
+	}
+
+	different := []QueryWrapper[TestQuery]{
+		build([]Filter{NewFilter("status", "active"), NewFilter("age", "30"), NewFilter("role", "admin").Not()}),
+		build([]Filter{NewFilter("status", "active"), NewFilter("age", 30), NewFilter("role", "admin")}),
+		NewQueryWrapperBuilder[TestQuery]().WithSortBy(NewDescendingSortBy("created_at")).WithFilter(a.Filter()).Build(),
+	}
+	for i, qw := range different {
+		if qw.CacheKey() == a.CacheKey() {
+			t.Errorf("Expected different key for case %d, got %s", i, qw.CacheKey())
+		}
+	}
+}
+
+func TestComponentKeys(t *testing.T) {
+	if key := NewPagination(10, 20).Key(); key != "10:20" {
+		t.Errorf("Unexpected pagination key %s", key)
+	}
+	if key := NewAscendingSortBy("name").Key(); key != "name:asc" {
+		t.Errorf("Unexpected sort key %s", key)
+	}
+	if key := FiltersKey([]Filter{NewFilter("b", 1), NewFilter("a", "x")}); key != `a="x"&b=1` {
+		t.Errorf("Unexpected filters key %s", key)
+	}
+	if FiltersKey(nil) != "" {
+		t.Error("Expected empty key for no filters")
+	}
+}
+
+func TestQueryWrapperClone(t *testing.T) {
+	ctx := context.Background()
+	original := NewQueryWrapper(
+		ctx,
+		TestQuery{Name: "clone", Age: 40},
+		NewSparseProjection([]string{"id", "name"}, []string{"name.last"}),
+		NewPagination(10, 0),
+		NewAscendingSortBy("name"),
+		[]Filter{NewFilter("status", "active")},
+	)
+
+	clone := original.Clone()
+	clone.Filter()[0] = NewFilter("status", "deleted")
+	clone.Projection().Fields()[0] = "email"
+	clone.Projection().Exclude()[0] = "id"
+
+	if clone.Context != ctx {
+		t.Error("Clone context not set correctly")
+	}
+	if clone.Query.Name != "clone" {
+		t.Error("Clone query not set correctly")
+	}
+	if original.Filter()[0].Value() != "active" {
+		t.Error("Mutating clone filters should not affect original")
+	}
+	if original.Projection().Fields()[0] != "id" || original.Projection().Exclude()[0] != "name.last" {
+		t.Error("Mutating clone projection should not affect original")
+	}
+}
+
 // CommandWrapper tests
 func TestNewCommandWrapper(t *testing.T) {
 	ctx := context.Background()
@@ -135,6 +332,37 @@ func TestCommandWrapperBuilder(t *testing.T) {
 	}
 }
 
+func TestCommandWrapperValidate(t *testing.T) {
+	ctx := context.Background()
+
+	invalid := NewCommandWrapper(ctx, ValidatedCommand{})
+	if err := invalid.Validate(); err == nil || err.Error() != "email is required" {
+		t.Errorf("Expected command validation error, got %v", err)
+	}
+
+	valid := NewCommandWrapper(ctx, ValidatedCommand{Email: "user@example.com"})
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Expected valid command, got %v", err)
+	}
+
+	plain := NewCommandWrapper(ctx, TestCommand{Action: "create"})
+	if err := plain.Validate(); err != nil {
+		t.Errorf("Expected nil for command without Validate, got %v", err)
+	}
+}
+
+func TestQueryWrapperValidate(t *testing.T) {
+	qw := NewQueryWrapperBuilder[ValidatedCommand]().Build()
+	if err := qw.Validate(); err == nil {
+		t.Error("Expected query validation error")
+	}
+
+	plain := NewQueryWrapperBuilder[TestQuery]().Build()
+	if err := plain.Validate(); err != nil {
+		t.Errorf("Expected nil for query without Validate, got %v", err)
+	}
+}
+
 // SortBy tests
 func TestNewSortBy(t *testing.T) {
 	sortBy := NewSortBy("name", true)
@@ -169,6 +397,25 @@ func TestNewDescendingSortBy(t *testing.T) {
 	}
 }
 
+func TestNewSort(t *testing.T) {
+	asc := NewSort("name", Asc)
+	if asc.Direction() != Asc || !asc.IsAscending() {
+		t.Error("Asc direction not set correctly")
+	}
+
+	desc := NewSort("name", Desc)
+	if desc.Direction() != Desc || desc.IsAscending() {
+		t.Error("Desc direction not set correctly")
+	}
+
+	if NewSortBy("name", true) != asc {
+		t.Error("NewSortBy(true) should match NewSort(Asc)")
+	}
+	if NewSortBy("name", false) != desc {
+		t.Error("NewSortBy(false) should match NewSort(Desc)")
+	}
+}
+
 func TestSortByValidate(t *testing.T) {
 	validSortBy := NewSortBy("name", true)
 	if err := validSortBy.Validate(); err != nil {
@@ -204,6 +451,39 @@ func TestNewFirstPagePagination(t *testing.T) {
 	}
 }
 
+func TestNewPageNumberPagination(t *testing.T) {
+	tests := []struct {
+		name           string
+		page, size     int
+		expectedLimit  int
+		expectedOffset int
+		expectedPage   int
+	}{
+		{"first page", 1, 20, 20, 0, 1},
+		{"third page", 3, 20, 20, 40, 3},
+		{"zero page clamped", 0, 20, 20, 0, 1},
+		{"negative page clamped", -2, 20, 20, 0, 1},
+		{"zero size clamped", 3, 0, 1, 2, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pagination := NewPageNumberPagination(tt.page, tt.size)
+			if pagination.Limit() != tt.expectedLimit || pagination.Offset() != tt.expectedOffset {
+				t.Errorf("Expected limit %d offset %d, got limit %d offset %d",
+					tt.expectedLimit, tt.expectedOffset, pagination.Limit(), pagination.Offset())
+			}
+			if pagination.PageNumber() != tt.expectedPage {
+				t.Errorf("Expected page %d, got %d", tt.expectedPage, pagination.PageNumber())
+			}
+		})
+	}
+
+	if NewPagination(0, 10).PageNumber() != 0 {
+		t.Error("Page number should be 0 without a positive limit")
+	}
+}
+
 func TestPaginationHasNext(t *testing.T) {
 	pagination := NewPagination(10, 0)
 
@@ -258,6 +538,59 @@ func TestPaginationValidate(t *testing.T) {
 	}
 }
 
+func TestPaginationValidateWithMax(t *testing.T) {
+	if err := NewPagination(100, 0).ValidateWithMax(100); err != nil {
+		t.Errorf("Limit at max should not return error: %v", err)
+	}
+
+	if err := NewPagination(101, 0).ValidateWithMax(100); err == nil {
+		t.Error("Limit above max should return error")
+	}
+
+	if err := NewPagination(0, 0).ValidateWithMax(100); err == nil {
+		t.Error("Zero limit should still return error")
+	}
+}
+
+func TestPaginationClamp(t *testing.T) {
+	clamped := NewPagination(500, 20).Clamp(100)
+	if clamped.Limit() != 100 {
+		t.Errorf("Clamped limit should be 100, got %d", clamped.Limit())
+	}
+	if clamped.Offset() != 20 {
+		t.Error("Clamp should keep the offset")
+	}
+
+	unchanged := NewPagination(50, 0).Clamp(100)
+	if unchanged.Limit() != 50 {
+		t.Errorf("Limit within bounds should be unchanged, got %d", unchanged.Limit())
+	}
+}
+
+func TestPaginationPageInfo(t *testing.T) {
+	tests := []struct {
+		name       string
+		pagination Pagination
+		total      int
+		expected   PageInfo
+	}{
+		{"first page", NewPagination(10, 0), 25, PageInfo{CurrentPage: 1, TotalPages: 3, HasNext: true, HasPrev: false, TotalCount: 25, PageSize: 10}},
+		{"middle page", NewPagination(10, 10), 25, PageInfo{CurrentPage: 2, TotalPages: 3, HasNext: true, HasPrev: true, TotalCount: 25, PageSize: 10}},
+		{"last partial page", NewPagination(10, 20), 25, PageInfo{CurrentPage: 3, TotalPages: 3, HasNext: false, HasPrev: true, TotalCount: 25, PageSize: 10}},
+		{"exact boundary", NewPagination(10, 10), 20, PageInfo{CurrentPage: 2, TotalPages: 2, HasNext: false, HasPrev: true, TotalCount: 20, PageSize: 10}},
+		{"empty result", NewPagination(10, 0), 0, PageInfo{CurrentPage: 1, TotalPages: 0, HasNext: false, HasPrev: false, TotalCount: 0, PageSize: 10}},
+		{"zero limit", NewPagination(0, 0), 25, PageInfo{CurrentPage: 0, TotalPages: 0, HasNext: false, HasPrev: false, TotalCount: 25, PageSize: 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pagination.PageInfo(tt.total); got != tt.expected {
+				t.Errorf("PageInfo(%d) = %+v, want %+v", tt.total, got, tt.expected)
+			}
+		})
+	}
+}
+
 // Filter tests
 func TestNewFilter(t *testing.T) {
 	filter := NewFilter("status", "active")
@@ -286,6 +619,91 @@ func TestFilterWithDifferentTypes(t *testing.T) {
 	}
 }
 
+func TestValidateFilters(t *testing.T) {
+	allowed := []string{"status", "type"}
+
+	if err := ValidateFilters([]Filter{NewFilter("status", "active")}, allowed); err != nil {
+		t.Errorf("Allowed filter should not return error: %v", err)
+	}
+
+	err := ValidateFilters([]Filter{
+		NewFilter("status", "active"),
+		NewFilter("salary", 1000),
+		NewFilter("ssn", "123"),
+		NewFilter("salary", 2000),
+	}, allowed)
+	if err == nil {
+		t.Fatal("Disallowed filters should return error")
+	}
+	if err.Error() != "filter fields not allowed: salary, ssn" {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+}
+
+func TestQueryWrapperValidateFilters(t *testing.T) {
+	filters := []Filter{NewFilter("salary", 1000)}
+
+	restricted := NewQueryWrapper(context.Background(), TestQuery{}, NewProjection([]string{"id", "name"}), NewFirstPagePagination(), NewAscendingSortBy("id"), filters)
+	if err := restricted.ValidateFilters(); err == nil {
+		t.Error("Filter outside projection should return error")
+	}
+
+	open := NewQueryWrapper(context.Background(), TestQuery{}, NewEmptyProjection(), NewFirstPagePagination(), NewAscendingSortBy("id"), filters)
+	if err := open.ValidateFilters(); err != nil {
+		t.Errorf("Empty projection should allow all filters: %v", err)
+	}
+
+	secret := []Filter{NewFilter("password", "x"), NewFilter("address.street", "Main")}
+	excluded := NewQueryWrapper(context.Background(), TestQuery{}, NewSparseProjection(nil, []string{"password", "address"}), NewFirstPagePagination(), NewAscendingSortBy("id"), secret)
+	err := excluded.ValidateFilters()
+	if err == nil || err.Error() != "filter fields not allowed: password, address.street" {
+		t.Errorf("Filters on excluded fields should return error, got %v", err)
+	}
+
+	included := NewQueryWrapper(context.Background(), TestQuery{}, NewSparseProjection([]string{"id", "password"}, []string{"password"}), NewFirstPagePagination(), NewAscendingSortBy("id"), secret[:1])
+	if err := included.ValidateFilters(); err == nil {
+		t.Error("Filter on an included but excluded field should return error")
+	}
+}
+
+func TestFiltersToMap(t *testing.T) {
+	filters := []Filter{
+		NewFilter("status", "active"),
+		NewFilter("type", "premium"),
+		NewFilter("status", "pending"),
+	}
+
+	byField := FiltersToMap(filters)
+	if len(byField) != 2 {
+		t.Errorf("Expected 2 fields, got %d", len(byField))
+	}
+	if byField["status"].Value() != "pending" {
+		t.Error("Last filter should win on duplicate fields")
+	}
+	if byField["type"].Value() != "premium" {
+		t.Error("Filter type not indexed correctly")
+	}
+}
+
+func TestFiltersByField(t *testing.T) {
+	filters := []Filter{
+		NewFilter("status", "active"),
+		NewFilter("type", "premium"),
+		NewFilter("status", "pending"),
+	}
+
+	grouped := FiltersByField(filters)
+	if len(grouped["status"]) != 2 {
+		t.Fatalf("Expected 2 status filters, got %d", len(grouped["status"]))
+	}
+	if grouped["status"][0].Value() != "active" || grouped["status"][1].Value() != "pending" {
+		t.Error("Duplicate filters should keep their order")
+	}
+	if len(grouped["type"]) != 1 {
+		t.Error("Filter type not grouped correctly")
+	}
+}
+
 // Projection tests
 func TestNewProjection(t *testing.T) {
 	fields := []string{"id", "name", "email"}
@@ -310,6 +728,30 @@ func TestNewEmptyProjection(t *testing.T) {
 	}
 }
 
+func TestProjectionResolve(t *testing.T) {
+	allFields := []string{"id", "name", "email", "address", "address.street", "address.city"}
+
+	tests := []struct {
+		name       string
+		projection Projection
+		expected   []string
+	}{
+		{"include only", NewProjection([]string{"id", "name"}), []string{"id", "name"}},
+		{"exclude only", NewSparseProjection(nil, []string{"email", "address.street"}), []string{"id", "name", "address", "address.city"}},
+		{"include and exclude", NewSparseProjection([]string{"id", "address.street", "address.city"}, []string{"address.street"}), []string{"id", "address.city"}},
+		{"exclude nested", NewSparseProjection(nil, []string{"address"}), []string{"id", "name", "email"}},
+		{"empty", NewEmptyProjection(), allFields},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.projection.Resolve(allFields); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
 // Page tests
 func TestPageMethods(t *testing.T) {
 	results := []TestResult{
@@ -352,6 +794,95 @@ func TestPageMethods(t *testing.T) {
 	}
 }
 
+func TestPageEach(t *testing.T) {
+	page := Page[TestResult]{
+		Results: []TestResult{
+			{ID: 1, Name: "test1"},
+			{ID: 2, Name: "test2"},
+			{ID: 3, Name: "test3"},
+		},
+	}
+
+	var visited []int
+	page.Each(func(i int, r TestResult) {
+		if r.ID != i+1 {
+			t.Errorf("Index %d does not match result %d", i, r.ID)
+		}
+		visited = append(visited, r.ID)
+	})
+
+	if len(visited) != 3 {
+		t.Errorf("Each should visit all results, visited %d", len(visited))
+	}
+}
+
+func TestPageFilter(t *testing.T) {
+	page := Page[TestResult]{
+		Results: []TestResult{
+			{ID: 1, Name: "test1"},
+			{ID: 2, Name: "test2"},
+			{ID: 3, Name: "test3"},
+		},
+		Offset:  20,
+		Limit:   10,
+		HasNext: true,
+	}
+
+	filtered := page.Filter(func(r TestResult) bool { return r.ID%2 == 1 })
+	if len(filtered.Results) != 2 {
+		t.Errorf("Expected 2 results, got %d", len(filtered.Results))
+	}
+	if filtered.Offset != 20 || filtered.Limit != 10 || !filtered.HasNext {
+		t.Error("Filter should keep pagination metadata")
+	}
+	if len(page.Results) != 3 {
+		t.Error("Filter should not modify the original page")
+	}
+
+	empty := page.Filter(func(r TestResult) bool { return false })
+	if empty.HasData() {
+		t.Error("HasData() should return false when no results match")
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	page := Page[TestResult]{
+		Results: []TestResult{
+			{ID: 1, Name: "test1"},
+			{ID: 2, Name: "test2"},
+			{ID: 3, Name: "test3"},
+			{ID: 4, Name: "test4"},
+			{ID: 5, Name: "test5"},
+		},
+	}
+
+	groups := GroupBy(page, func(r TestResult) string {
+		if r.ID%2 == 0 {
+			return "even"
+		}
+		return "odd"
+	})
+
+	if len(groups) != 2 {
+		t.Fatalf("Expected 2 groups, got %d", len(groups))
+	}
+	odd := groups["odd"]
+	if len(odd) != 3 || odd[0].ID != 1 || odd[1].ID != 3 || odd[2].ID != 5 {
+		t.Errorf("Unexpected odd bucket: %v", odd)
+	}
+	even := groups["even"]
+	if len(even) != 2 || even[0].ID != 2 || even[1].ID != 4 {
+		t.Errorf("Unexpected even bucket: %v", even)
+	}
+	if len(page.Results) != 5 || page.Results[1].ID != 2 {
+		t.Error("GroupBy should not modify the page")
+	}
+
+	if len(GroupBy(Page[TestResult]{}, func(r TestResult) int { return r.ID })) != 0 {
+		t.Error("GroupBy of an empty page should return no groups")
+	}
+}
+
 func TestPagesBuilder(t *testing.T) {
 	results := []TestResult{
 		{ID: 1, Name: "test1"},
@@ -386,6 +917,38 @@ func TestPagesBuilder(t *testing.T) {
 	}
 }
 
+func TestPagesBuilderCursors(t *testing.T) {
+	page := NewPagesBuilder[TestResult]().
+		Results([]TestResult{{ID: 11, Name: "test11"}}).
+		Limit(10).
+		NextCursor("eyJpZCI6MTF9").
+		PrevCursor("eyJpZCI6MTB9").
+		Build()
+
+	if !page.HasNext || page.NextCursor != "eyJpZCI6MTF9" {
+		t.Error("NextCursor not set correctly")
+	}
+	if !page.HasPrev || page.PrevCursor != "eyJpZCI6MTB9" {
+		t.Error("PrevCursor not set correctly")
+	}
+
+	data, err := json.Marshal(page)
+	if err != nil {
+		t.Fatalf("Failed to marshal page: %v", err)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal page: %v", err)
+	}
+	if decoded["NextCursor"] != "eyJpZCI6MTF9" || decoded["PrevCursor"] != "eyJpZCI6MTB9" {
+		t.Errorf("Cursors not serialized: %s", data)
+	}
+	if decoded["HasPrev"] != true {
+		t.Errorf("HasPrev not serialized: %s", data)
+	}
+}
+
 func TestPagesBuilderEmpty(t *testing.T) {
 	builder := NewPagesBuilder[TestResult]()
 	page := builder.Build()
@@ -485,13 +1048,13 @@ func TestCommandWrapperIntegration(t *testing.T) {
 func TestErrorHandling(t *testing.T) {
 	// Test SortBy validation errors
 	t.Run("SortBy validation", func(t *testing.T) {
-		invalidSort := SortBy{field: "", ascending: true}
+		invalidSort := SortBy{field: "", direction: Asc}
 		err := invalidSort.Validate()
 		if err == nil {
 			t.Error("Should return error for empty field")
 		}
-		if !errors.Is(err, errors.New("sort field cannot be empty")) {
-			t.Log("Error message is as expected")
+		if !errors.Is(err, ErrEmptySortField) {
+			t.Errorf("Expected ErrEmptySortField, got %v", err)
 		}
 	})
 
@@ -502,6 +1065,22 @@ func TestErrorHandling(t *testing.T) {
 		if err == nil {
 			t.Error("Should return error for invalid pagination")
 		}
+		if !errors.Is(err, ErrInvalidLimit) {
+			t.Errorf("Expected ErrInvalidLimit, got %v", err)
+		}
+
+		negativeOffset := Pagination{limit: 10, offset: -1}
+		if err := negativeOffset.Validate(); !errors.Is(err, ErrNegativeOffset) {
+			t.Errorf("Expected ErrNegativeOffset, got %v", err)
+		}
+	})
+
+	// Test errors surfaced through request parsing
+	t.Run("ParseQueryWrapper validation", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/users?limit=0", nil)
+		if _, err := ParseQueryWrapper(req, TestQuery{}, nil, nil); !errors.Is(err, ErrInvalidLimit) {
+			t.Errorf("Expected ErrInvalidLimit, got %v", err)
+		}
 	})
 }
 
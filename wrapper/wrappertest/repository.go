@@ -0,0 +1,65 @@
+// Package wrappertest provides test doubles for the wrapper package
+package wrappertest
+
+import (
+	"sync"
+
+	"github.com/l00pss/helpme/wrapper"
+)
+
+// FakeRepository is a wrapper.Repository that records its calls. Find calls
+// FindFunc when it is set, and returns Page and Err otherwise.
+type FakeRepository[Q, R any] struct {
+	FindFunc func(qw wrapper.QueryWrapper[Q]) (wrapper.Page[R], error)
+	Page     wrapper.Page[R]
+	Err      error
+
+	mu    sync.Mutex
+	calls []wrapper.QueryWrapper[Q]
+}
+
+var _ wrapper.Repository[struct{}, struct{}] = (*FakeRepository[struct{}, struct{}])(nil)
+
+// NewFakeRepository creates a fake repository that calls find
+func NewFakeRepository[Q, R any](find func(qw wrapper.QueryWrapper[Q]) (wrapper.Page[R], error)) *FakeRepository[Q, R] {
+	return &FakeRepository[Q, R]{FindFunc: find}
+}
+
+// Find records qw and returns the configured page or error
+func (f *FakeRepository[Q, R]) Find(qw wrapper.QueryWrapper[Q]) (wrapper.Page[R], error) {
+	f.mu.Lock()
+	f.calls = append(f.calls, qw)
+	find := f.FindFunc
+	f.mu.Unlock()
+
+	if find != nil {
+		return find(qw)
+	}
+	return f.Page, f.Err
+}
+
+// Calls returns the queries passed to Find, in call order
+func (f *FakeRepository[Q, R]) Calls() []wrapper.QueryWrapper[Q] {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	calls := make([]wrapper.QueryWrapper[Q], len(f.calls))
+	copy(calls, f.calls)
+	return calls
+}
+
+// CallCount returns how many times Find was called
+func (f *FakeRepository[Q, R]) CallCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.calls)
+}
+
+// Reset discards the recorded calls
+func (f *FakeRepository[Q, R]) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = nil
+}
@@ -0,0 +1,96 @@
+package wrappertest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/l00pss/helpme/wrapper"
+)
+
+type userQuery struct {
+	Name string
+}
+
+// fetchAll is a service function that pages through repo until there is no next page
+func fetchAll(repo wrapper.Repository[userQuery, int], query userQuery, limit int) ([]int, error) {
+	var all []int
+	pagination := wrapper.NewPagination(limit, 0)
+	for {
+		qw := wrapper.NewQueryWrapperBuilder[userQuery]().
+			WithContext(context.Background()).
+			WithQuery(query).
+			WithPagination(pagination).
+			Build()
+
+		page, err := repo.Find(qw)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page.Results...)
+		if !page.Next() {
+			return all, nil
+		}
+		pagination = pagination.NextPage()
+	}
+}
+
+func TestFakeRepositoryPaginates(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5}
+	repo := NewFakeRepository(func(qw wrapper.QueryWrapper[userQuery]) (wrapper.Page[int], error) {
+		p := qw.Pagination()
+		end := min(p.Offset()+p.Limit(), len(data))
+		return wrapper.NewPagesBuilder[int]().
+			Results(data[p.Offset():end]).
+			Offset(p.Offset()).
+			Limit(p.Limit()).
+			HasNext(p.HasNext(len(data))).
+			Build(), nil
+	})
+
+	all, err := fetchAll(repo, userQuery{Name: "alice"}, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(all) != len(data) {
+		t.Errorf("Expected %d results, got %v", len(data), all)
+	}
+
+	calls := repo.Calls()
+	if len(calls) != 3 || repo.CallCount() != 3 {
+		t.Fatalf("Expected 3 calls, got %d", len(calls))
+	}
+	for i, call := range calls {
+		if call.Pagination().Offset() != i*2 {
+			t.Errorf("Call %d: expected offset %d, got %d", i, i*2, call.Pagination().Offset())
+		}
+		if call.Query.Name != "alice" {
+			t.Errorf("Call %d: expected query to be recorded, got %+v", i, call.Query)
+		}
+	}
+
+	repo.Reset()
+	if repo.CallCount() != 0 {
+		t.Error("Expected Reset to discard recorded calls")
+	}
+}
+
+func TestFakeRepositoryConfiguredResult(t *testing.T) {
+	repo := &FakeRepository[userQuery, int]{
+		Page: wrapper.Page[int]{Results: []int{7}},
+	}
+
+	all, err := fetchAll(repo, userQuery{}, 10)
+	if err != nil || len(all) != 1 || all[0] != 7 {
+		t.Errorf("Expected configured page, got %v, %v", all, err)
+	}
+
+	errNotFound := errors.New("not found")
+	repo.Err = errNotFound
+	if _, err := fetchAll(repo, userQuery{}, 10); !errors.Is(err, errNotFound) {
+		t.Errorf("Expected configured error, got %v", err)
+	}
+	if repo.CallCount() != 2 {
+		t.Errorf("Expected 2 calls, got %d", repo.CallCount())
+	}
+}